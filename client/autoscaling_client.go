@@ -92,6 +92,40 @@ func (c *Client) GetScalerGroup(scaleGroupID, projectID, location string) (*mode
 	return &response.Data, nil
 }
 
+func (c *Client) ListScalerGroups(projectID, location string) ([]models.ScalerGroupGetDetail, error) {
+	url := c.Api_endpoint + "/scaler/scalegroups/"
+	log.Printf("[INFO] Listing Scaler Groups")
+
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GET request: %v", err)
+	}
+	httpReq = addParamsAndHeaders(httpReq, c.Api_key, c.Auth_token, projectID, location)
+
+	resp, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list scaler groups failed: status %d\nresponse: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response models.ListScalerGroupsResponse
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode list response: %v\nresponse body: %s", err, string(bodyBytes))
+	}
+
+	log.Printf("[INFO] Found %d Scaler Group(s)", len(response.Data))
+	return response.Data, nil
+}
+
 func (c *Client) DeleteScalerGroup(scaleGroupID, projectID, location string) error {
 	url := c.Api_endpoint + "/scaler/scalegroups/" + scaleGroupID + "/"
 	log.Printf("[INFO] Sending delete request for Scaler Group ID: %s", scaleGroupID)
@@ -340,6 +374,43 @@ func (c *Client) UpdateDesiredNodeCount(scalerGroupID int, desired int, projectI
 	return nil
 }
 
+// RemoveScalerGroupNodes removes the given members of a scaler group through
+// the group, so the autoscaler doesn't launch them again.
+func (c *Client) RemoveScalerGroupNodes(scalerGroupID string, req *models.RemoveScalerGroupNodesRequest, projectID, location string) error {
+	url := c.Api_endpoint + "/scaler/scalegroups/" + scalerGroupID + "/nodes/remove/"
+	log.Printf("[INFO] Removing nodes %v from Scaler Group %s (decrement desired: %t)", req.NodeIDs, scalerGroupID, req.DecrementDesired)
+
+	payloadBuf := new(bytes.Buffer)
+	if err := json.NewEncoder(payloadBuf).Encode(req); err != nil {
+		return fmt.Errorf("failed to encode remove nodes payload: %v", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", url, payloadBuf)
+	if err != nil {
+		return fmt.Errorf("failed to create POST request: %v", err)
+	}
+
+	httpReq = addParamsAndHeaders(httpReq, c.Api_key, c.Auth_token, projectID, location)
+
+	resp, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("remove scaler group nodes failed: status %d\nresponse: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	log.Printf("[INFO] Nodes %v removed from Scaler Group %s", req.NodeIDs, scalerGroupID)
+	return nil
+}
+
 func (c *Client) UpdateScalerGroupStatus(id int, status, projectID, location string) error {
 	var url string
 	idStr := strconv.Itoa(id)
@@ -643,6 +714,38 @@ func (c *Client) GetAttachedVPCsForScalerGroup(scalerGroupID, projectID, locatio
 	return result.Data, nil
 }
 
+func (c *Client) GetScalerGroupSecurityGroups(scalerGroupID, projectID, location string) ([]models.ScalerSecurityGroup, error) {
+	url := c.Api_endpoint + "/scaler/scalegroups/security_groups/" + scalerGroupID + "/"
+	log.Printf("[INFO] Fetching Security Groups of Scaler Group %s", scalerGroupID)
+
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GET request: %v", err)
+	}
+	httpReq = addParamsAndHeaders(httpReq, c.Api_key, c.Auth_token, projectID, location)
+
+	resp, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get scaler group security groups failed: status %d\nresponse: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var response models.GetScalerSecurityGroupsResponse
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode security group response: %v\nresponse body: %s", err, string(bodyBytes))
+	}
+	return response.Data, nil
+}
+
 func (c *Client) DetachSecurityGroupFromScalergroup(scalerGroupID string, sgID int, projectID, location string) error {
 	url := c.Api_endpoint + "/scaler/scalegroups/security_groups/" + scalerGroupID + "/"
 	log.Printf("[INFO] Detaching Security Group %d from Scaler Group %s", sgID, scalerGroupID)
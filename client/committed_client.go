@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+// GetNodePlans returns the node plans offered for an image, with their
+// committed SKUs.
+func (c *Client) GetNodePlans(image, projectID, location string) ([]models.NodePlan, error) {
+	url := c.Api_endpoint + "nodes/plans/"
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = addParamsAndHeaders(req, c.Api_key, c.Auth_token, projectID, location)
+	q := req.URL.Query()
+	q.Add("image", image)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		log.Printf("[ERROR] error inside GetNodePlans: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got a non 200 status code: %v - %s", resp.StatusCode, string(body))
+	}
+
+	var res models.NodePlansResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		log.Printf("[ERROR] unmarshalling GetNodePlans response: %v", err)
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// UpdateNodeCommittedSettings converts a node to a committed SKU or changes
+// what happens at the end of its committed term.
+func (c *Client) UpdateNodeCommittedSettings(nodeId string, settings *models.CommittedSettingsRequest, projectID, location string) error {
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	url := c.Api_endpoint + "nodes/" + nodeId + "/committed-settings/"
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req = addParamsAndHeaders(req, c.Api_key, c.Auth_token, projectID, location)
+
+	log.Printf("[INFO] node %s | committed settings %+v", nodeId, *settings)
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("got a non 200 status code: %v - %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+// The rds/cluster endpoints are shared by every DBaaS engine, the engine is
+// only chosen through the software_id of the create request.
+
+func (c *Client) CreateDBaaSCluster(req *models.DBCreateRequest, projectID, location string) (*models.DB, error) {
+	log.Printf("[INFO] Creating DBaaS cluster %s", req.Name)
+	body, err := c.doDBaaSClusterRequest("POST", "", req, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("create DBaaS cluster failed: %v", err)
+	}
+	return decodeDBaaSCluster(body)
+}
+
+func (c *Client) GetDBaaSCluster(id, projectID, location string) (*models.DB, error) {
+	body, err := c.doDBaaSClusterRequest("GET", id+"/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("get DBaaS cluster %s failed: %v", id, err)
+	}
+	return decodeDBaaSCluster(body)
+}
+
+func (c *Client) DeleteDBaaSCluster(id, projectID, location string) error {
+	log.Printf("[INFO] Deleting DBaaS cluster %s", id)
+	if _, err := c.doDBaaSClusterRequest("DELETE", id+"/", nil, projectID, location); err != nil {
+		return fmt.Errorf("delete DBaaS cluster %s failed: %v", id, err)
+	}
+	return nil
+}
+
+func (c *Client) ShutdownDBaaSCluster(id, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "shutdown", nil, projectID, location)
+}
+
+func (c *Client) ResumeDBaaSCluster(id, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "resume", nil, projectID, location)
+}
+
+func (c *Client) RestartDBaaSCluster(id, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "restart", nil, projectID, location)
+}
+
+func (c *Client) AttachVPCToDBaaSCluster(id string, vpcs []models.VPC, projectID, location string) error {
+	payload := models.AttachVPCPayloadRequest{Action: "attach", VPCs: vpcs}
+	return c.doDBaaSClusterAction(id, "vpc-attach/", payload, projectID, location)
+}
+
+func (c *Client) DetachVPCFromDBaaSCluster(id string, vpcs []models.VPC, projectID, location string) error {
+	payload := models.AttachVPCPayloadRequest{Action: "detach", VPCs: vpcs}
+	return c.doDBaaSClusterAction(id, "vpc-detach/", payload, projectID, location)
+}
+
+func (c *Client) AttachPublicIPToDBaaSCluster(id, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "public-ip-attach/", nil, projectID, location)
+}
+
+func (c *Client) DetachPublicIPFromDBaaSCluster(id, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "public-ip-detach/", nil, projectID, location)
+}
+
+// AttachAllowedIPsToDBaaSCluster adds IPs to the whitelist of the cluster.
+// A cluster with an empty whitelist accepts connections from any IP.
+func (c *Client) AttachAllowedIPsToDBaaSCluster(id string, ips []models.AllowedIP, projectID, location string) error {
+	payload := models.AllowedIPRequest{Action: "attach", IPAddresses: ips}
+	return c.doDBaaSClusterAction(id, "whitelisted-ip-attach/", payload, projectID, location)
+}
+
+func (c *Client) DetachAllowedIPsFromDBaaSCluster(id string, ips []models.AllowedIP, projectID, location string) error {
+	payload := models.AllowedIPRequest{Action: "detach", IPAddresses: ips}
+	return c.doDBaaSClusterAction(id, "whitelisted-ip-detach/", payload, projectID, location)
+}
+
+func (c *Client) AttachParameterGroupToDBaaSCluster(id string, parameterGroupID int, projectID, location string) error {
+	payload := models.ParameterGroupRequest{Action: "add"}
+	return c.doDBaaSClusterAction(id, "parameter-group/"+strconv.Itoa(parameterGroupID)+"/add", payload, projectID, location)
+}
+
+func (c *Client) DetachParameterGroupFromDBaaSCluster(id string, parameterGroupID int, projectID, location string) error {
+	payload := models.ParameterGroupRequest{Action: "detach"}
+	return c.doDBaaSClusterAction(id, "parameter-group/"+strconv.Itoa(parameterGroupID)+"/detach", payload, projectID, location)
+}
+
+func (c *Client) UpgradeDBaaSClusterPlan(id string, templateID int, projectID, location string) error {
+	payload := models.UpgradePlanRequest{TemplateID: templateID}
+	return c.doDBaaSClusterAction(id, "rds-upgrade/", payload, projectID, location)
+}
+
+// ExpandDBaaSClusterDisk grows the disk of the cluster by additionalSize GB.
+func (c *Client) ExpandDBaaSClusterDisk(id string, additionalSize int, projectID, location string) error {
+	payload := models.DiskUpgradeRequest{Size: additionalSize}
+	return c.doDBaaSClusterAction(id, "disk-upgrade/", payload, projectID, location)
+}
+
+// UpdateDBaaSClusterMaintenanceWindow sets the weekly window the cluster is
+// patched in.
+func (c *Client) UpdateDBaaSClusterMaintenanceWindow(id string, window *models.DBMaintenanceWindow, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "maintenance-window/", window, projectID, location)
+}
+
+// UpdateDBaaSClusterBackupSettings sets the daily backup time, how long
+// backups are kept and whether point in time recovery is enabled.
+func (c *Client) UpdateDBaaSClusterBackupSettings(id string, settings *models.DBBackupSettings, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "backup-settings/", settings, projectID, location)
+}
+
+// UpdateDBaaSClusterCommittedSettings converts a cluster to a committed SKU
+// or changes what happens at the end of its committed term.
+func (c *Client) UpdateDBaaSClusterCommittedSettings(id string, settings *models.CommittedSettingsRequest, projectID, location string) error {
+	return c.doDBaaSClusterAction(id, "committed-settings/", settings, projectID, location)
+}
+
+func (c *Client) doDBaaSClusterAction(id, action string, payload interface{}, projectID, location string) error {
+	log.Printf("[INFO] DBaaS cluster %s | action %s", id, action)
+	if _, err := c.doDBaaSClusterRequest("PUT", id+"/"+action, payload, projectID, location); err != nil {
+		return fmt.Errorf("DBaaS cluster %s %s failed: %v", id, action, err)
+	}
+	return nil
+}
+
+func (c *Client) doDBaaSClusterRequest(method, path string, payload interface{}, projectID, location string) ([]byte, error) {
+	return c.doDBaaSRequest(method, "cluster/"+path, payload, projectID, location)
+}
+
+// doDBaaSRequest sends a request to an rds endpoint and returns the body of
+// a 200 response.
+func (c *Client) doDBaaSRequest(method, path string, payload interface{}, projectID, location string) ([]byte, error) {
+	url := c.Api_endpoint + "rds/" + path
+
+	buf := bytes.Buffer{}
+	if payload != nil {
+		if err := json.NewEncoder(&buf).Encode(payload); err != nil {
+			return nil, fmt.Errorf("failed to encode payload: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req = addParamsAndHeaders(req, c.Api_key, c.Auth_token, projectID, location)
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+func decodeDBaaSCluster(body []byte) (*models.DB, error) {
+	var response models.DBResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return &response.Data, nil
+}
+
+// ExpandVpcList resolves VPC IDs to the network details the cluster endpoints
+// expect. Only active VPCs can be attached.
+func (c *Client) ExpandVpcList(vpcIDs []interface{}, projectID, location string) ([]models.VPC, error) {
+	vpcDetails := []models.VPC{}
+
+	for _, id := range vpcIDs {
+		vpcResp, err := c.GetVpc(strconv.Itoa(id.(int)), projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		data := vpcResp.Data
+		if data.State != "Active" {
+			return nil, fmt.Errorf("cannot attach VPC %s: VPC is in '%s' state", data.Name, data.State)
+		}
+
+		vpcDetails = append(vpcDetails, models.VPC{
+			Network_id: data.Network_id,
+			VpcName:    data.Name,
+			Ipv4_cidr:  data.Ipv4_cidr,
+		})
+	}
+	return vpcDetails, nil
+}
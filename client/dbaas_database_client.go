@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+// Users and logical databases of a cluster are addressed by their name.
+
+func (c *Client) CreateDBaaSUser(clusterID string, req *models.DBUserRequest, projectID, location string) error {
+	log.Printf("[INFO] Creating user %s of DBaaS cluster %s", req.Username, clusterID)
+	if _, err := c.doDBaaSClusterRequest("POST", clusterID+"/user/", req, projectID, location); err != nil {
+		return fmt.Errorf("create user %s of DBaaS cluster %s failed: %v", req.Username, clusterID, err)
+	}
+	return nil
+}
+
+func (c *Client) ListDBaaSUsers(clusterID, projectID, location string) ([]models.DBUser, error) {
+	body, err := c.doDBaaSClusterRequest("GET", clusterID+"/user/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("list users of DBaaS cluster %s failed: %v", clusterID, err)
+	}
+	var response models.DBUsersResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return response.Data, nil
+}
+
+// GetDBaaSUser returns a user of a cluster, nil when there is no such user.
+func (c *Client) GetDBaaSUser(clusterID, username, projectID, location string) (*models.DBUser, error) {
+	users, err := c.ListDBaaSUsers(clusterID, projectID, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpdateDBaaSUserPrivileges replaces the privileges of a user.
+func (c *Client) UpdateDBaaSUserPrivileges(clusterID, username string, privileges, databases []string, projectID, location string) error {
+	log.Printf("[INFO] Updating privileges of user %s of DBaaS cluster %s", username, clusterID)
+	req := models.DBUserRequest{Privileges: privileges, Databases: databases}
+	if _, err := c.doDBaaSClusterRequest("PUT", clusterID+"/user/"+url.PathEscape(username)+"/", req, projectID, location); err != nil {
+		return fmt.Errorf("update privileges of user %s of DBaaS cluster %s failed: %v", username, clusterID, err)
+	}
+	return nil
+}
+
+func (c *Client) RotateDBaaSUserPassword(clusterID, username, password, projectID, location string) error {
+	log.Printf("[INFO] Rotating password of user %s of DBaaS cluster %s", username, clusterID)
+	req := models.DBUserPasswordRequest{Password: password}
+	if _, err := c.doDBaaSClusterRequest("PUT", clusterID+"/user/"+url.PathEscape(username)+"/password/", req, projectID, location); err != nil {
+		return fmt.Errorf("rotate password of user %s of DBaaS cluster %s failed: %v", username, clusterID, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteDBaaSUser(clusterID, username, projectID, location string) error {
+	log.Printf("[INFO] Deleting user %s of DBaaS cluster %s", username, clusterID)
+	if _, err := c.doDBaaSClusterRequest("DELETE", clusterID+"/user/"+url.PathEscape(username)+"/", nil, projectID, location); err != nil {
+		return fmt.Errorf("delete user %s of DBaaS cluster %s failed: %v", username, clusterID, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateDBaaSDatabase(clusterID string, req *models.DBDatabaseRequest, projectID, location string) error {
+	log.Printf("[INFO] Creating database %s of DBaaS cluster %s", req.Name, clusterID)
+	if _, err := c.doDBaaSClusterRequest("POST", clusterID+"/database/", req, projectID, location); err != nil {
+		return fmt.Errorf("create database %s of DBaaS cluster %s failed: %v", req.Name, clusterID, err)
+	}
+	return nil
+}
+
+func (c *Client) ListDBaaSDatabases(clusterID, projectID, location string) ([]models.DBDatabase, error) {
+	body, err := c.doDBaaSClusterRequest("GET", clusterID+"/database/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("list databases of DBaaS cluster %s failed: %v", clusterID, err)
+	}
+	var response models.DBDatabasesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return response.Data, nil
+}
+
+// GetDBaaSDatabase returns a logical database of a cluster, nil when there
+// is no such database.
+func (c *Client) GetDBaaSDatabase(clusterID, name, projectID, location string) (*models.DBDatabase, error) {
+	databases, err := c.ListDBaaSDatabases(clusterID, projectID, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, database := range databases {
+		if database.Name == name {
+			return &database, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) UpdateDBaaSDatabaseOwner(clusterID, name, owner, projectID, location string) error {
+	log.Printf("[INFO] Changing owner of database %s of DBaaS cluster %s to %s", name, clusterID, owner)
+	req := models.DBDatabaseRequest{Owner: owner}
+	if _, err := c.doDBaaSClusterRequest("PUT", clusterID+"/database/"+url.PathEscape(name)+"/", req, projectID, location); err != nil {
+		return fmt.Errorf("change owner of database %s of DBaaS cluster %s failed: %v", name, clusterID, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteDBaaSDatabase(clusterID, name, projectID, location string) error {
+	log.Printf("[INFO] Deleting database %s of DBaaS cluster %s", name, clusterID)
+	if _, err := c.doDBaaSClusterRequest("DELETE", clusterID+"/database/"+url.PathEscape(name)+"/", nil, projectID, location); err != nil {
+		return fmt.Errorf("delete database %s of DBaaS cluster %s failed: %v", name, clusterID, err)
+	}
+	return nil
+}
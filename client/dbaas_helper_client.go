@@ -3,47 +3,61 @@ package client
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func (c *Client) GetSoftwareId(projectID string, location string, name string, version string) (int, error) {
+// GetDBaaSPlans returns the DBaaS catalogue of a location. The template
+// plans are only listed when a software ID is given.
+func (c *Client) GetDBaaSPlans(projectID string, location string, softwareID string) (*models.PlanData, error) {
 	url := c.Api_endpoint + "rds/plans/"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	req = addParamsAndHeaders(req, c.Api_key, c.Auth_token, projectID, location)
 
+	if softwareID != "" {
+		q := req.URL.Query()
+		q.Add("software_id", softwareID)
+		req.URL.RawQuery = q.Encode()
+	}
+
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
-		log.Printf("[ERROR] error inside GetSoftwareId: %v", err)
-		return -1, err
+		log.Printf("[ERROR] error inside GetDBaaSPlans: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("[ERROR] reading GetSoftwareId response: %v", err)
-		return -1, err
+		log.Printf("[ERROR] reading GetDBaaSPlans response: %v", err)
+		return nil, err
 	}
 	log.Println("[DEBUG] Raw body:", string(body))
 
 	var res models.PlanResponse
 	if err := json.Unmarshal(body, &res); err != nil {
-		log.Printf("[ERROR] unmarshalling GetSoftwareId response: %v", err)
+		log.Printf("[ERROR] unmarshalling GetDBaaSPlans response: %v", err)
+		return nil, err
+	}
+
+	return &res.Data, nil
+}
+
+func (c *Client) GetSoftwareId(projectID string, location string, name string, version string) (int, error) {
+	plans, err := c.GetDBaaSPlans(projectID, location, "")
+	if err != nil {
 		return -1, err
 	}
 
-	for _, item := range res.Data.DatabaseEngines {
+	for _, item := range plans.DatabaseEngines {
 		if item.EngineName == name && item.EngineVersion == version {
 			return item.EngineID, nil
 		}
@@ -54,93 +68,26 @@ func (c *Client) GetSoftwareId(projectID string, location string, name string, v
 }
 
 func (c *Client) GetTemplateId(projectID string, location string, plan string, softwareID string) (int, error) {
-	url := c.Api_endpoint + "rds/plans/"
-
-	req, err := http.NewRequest("GET", url, nil)
+	template, err := c.GetDBaaSPlan(projectID, location, plan, softwareID)
 	if err != nil {
 		return -1, err
 	}
+	return template.PlanTemplateID, nil
+}
 
-	req = addParamsAndHeaders(req, c.Api_key, c.Auth_token, projectID, location)
-
-	
-	q := req.URL.Query()
-	q.Add("software_id", softwareID)
-	req.URL.RawQuery = q.Encode()
-
-	resp, err := c.HttpClient.Do(req)
+// GetDBaaSPlan returns the template plan of a software by plan name.
+func (c *Client) GetDBaaSPlan(projectID string, location string, plan string, softwareID string) (*models.PlanTemplate, error) {
+	plans, err := c.GetDBaaSPlans(projectID, location, softwareID)
 	if err != nil {
-		log.Printf("[ERROR] error inside GetTemplateId: %v", err)
-		return -1, err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("[ERROR] reading GetTemplateId response: %v", err)
-		return -1, err
-	}
-
-	var res models.PlanResponse
-	if err := json.Unmarshal(body, &res); err != nil {
-		log.Printf("[ERROR] unmarshalling GetTemplateId response: %v", err)
-		return -1, err
-	}
-
-	for _, item := range res.Data.TemplatePlans {
+	for _, item := range plans.TemplatePlans {
 		if item.PlanName == plan {
-			return item.PlanTemplateID, nil
+			return &item, nil
 		}
 	}
 
 	log.Printf("[INFO] Template ID not found for plan name: %s", plan)
-	return -1, errors.New("matching plan not found")
-}
-
-func (c *Client) ExpandVpcList(d *schema.ResourceData, vpcList []interface{}) ([]models.VPC, error) {
-	var vpcDetails []models.VPC
-
-	for _, id := range vpcList {
-		vpcResp, err := c.GetVpc(strconv.Itoa(id.(int)), d.Get("project_id").(string), d.Get("location").(string))
-		if err != nil {
-			return nil, err
-		}
-		data := vpcResp.Data
-		if data.State != "Active" {
-			return nil, fmt.Errorf("cannot attach VPC %s: VPC is in '%s' state", data.Name, data.State)
-		}
-
-		vpcDetails = append(vpcDetails, models.VPC{
-			Network_id: data.Network_id,
-			VpcName:    data.Name,
-			Ipv4_cidr:  data.Ipv4_cidr,
-		})
-	}
-	return vpcDetails, nil
-}
-
-func (c *Client) ExpandMariaDBVpcList(vpcIDs []string, projectID, location string) ([]models.VPCMetadata, error) {
-	var vpcDetails []models.VPCMetadata
-
-	for _, id := range vpcIDs {
-		vpcResp, err := c.GetVpc(id, projectID, location)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch VPC details for ID %s: %v", id, err)
-		}
-
-		vpc := vpcResp.Data
-		if vpc.State != "Active" {
-			return nil, fmt.Errorf("cannot attach VPC %s: VPC is in '%s' state", id, vpc.State)
-		}
-
-		networkID := fmt.Sprintf("%.0f", vpc.Network_id)
-
-		vpcDetails = append(vpcDetails, models.VPCMetadata{
-			NetworkID: networkID,
-			VPCName:   vpc.Name,
-			IPv4CIDR:  vpc.Ipv4_cidr,
-		})
-	}
-
-	return vpcDetails, nil
+	return nil, errors.New("matching plan not found")
 }
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+func (c *Client) CreateDBaaSParameterGroup(req *models.ParameterGroupCreateRequest, projectID, location string) (*models.ParameterGroup, error) {
+	log.Printf("[INFO] Creating DBaaS parameter group %s", req.Name)
+	body, err := c.doDBaaSRequest("POST", "parameter-group/", req, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("create DBaaS parameter group failed: %v", err)
+	}
+	return decodeDBaaSParameterGroup(body)
+}
+
+// GetDBaaSParameterGroup returns the parameter group with every parameter of
+// its engine, set or default.
+func (c *Client) GetDBaaSParameterGroup(id, projectID, location string) (*models.ParameterGroup, error) {
+	body, err := c.doDBaaSRequest("GET", "parameter-group/"+id+"/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("get DBaaS parameter group %s failed: %v", id, err)
+	}
+	return decodeDBaaSParameterGroup(body)
+}
+
+func (c *Client) UpdateDBaaSParameterGroup(id string, req *models.ParameterGroupUpdateRequest, projectID, location string) error {
+	log.Printf("[INFO] Updating DBaaS parameter group %s", id)
+	if _, err := c.doDBaaSRequest("PUT", "parameter-group/"+id+"/", req, projectID, location); err != nil {
+		return fmt.Errorf("update DBaaS parameter group %s failed: %v", id, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteDBaaSParameterGroup(id, projectID, location string) error {
+	log.Printf("[INFO] Deleting DBaaS parameter group %s", id)
+	if _, err := c.doDBaaSRequest("DELETE", "parameter-group/"+id+"/", nil, projectID, location); err != nil {
+		return fmt.Errorf("delete DBaaS parameter group %s failed: %v", id, err)
+	}
+	return nil
+}
+
+func decodeDBaaSParameterGroup(body []byte) (*models.ParameterGroup, error) {
+	var response models.ParameterGroupResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return &response.Data, nil
+}
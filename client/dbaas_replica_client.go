@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+// Read replicas are nodes of the cluster they replicate, addressed by their
+// node ID.
+
+func (c *Client) CreateDBaaSReplica(clusterID string, req *models.DBReplicaCreateRequest, projectID, location string) (*models.DBNode, error) {
+	log.Printf("[INFO] Creating read replica %s of DBaaS cluster %s", req.Name, clusterID)
+	body, err := c.doDBaaSClusterRequest("POST", clusterID+"/replica/", req, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("create read replica of DBaaS cluster %s failed: %v", clusterID, err)
+	}
+	var response models.DBReplicaResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return &response.Data, nil
+}
+
+func (c *Client) ListDBaaSReplicas(clusterID, projectID, location string) ([]models.DBNode, error) {
+	body, err := c.doDBaaSClusterRequest("GET", clusterID+"/replica/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("list read replicas of DBaaS cluster %s failed: %v", clusterID, err)
+	}
+	var response models.DBReplicasResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return response.Data, nil
+}
+
+// GetDBaaSReplica returns a read replica of a cluster, nil when there is no
+// such replica.
+func (c *Client) GetDBaaSReplica(clusterID, replicaID, projectID, location string) (*models.DBNode, error) {
+	replicas, err := c.ListDBaaSReplicas(clusterID, projectID, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, replica := range replicas {
+		if strconv.Itoa(replica.NodeID) == replicaID {
+			return &replica, nil
+		}
+	}
+	return nil, nil
+}
+
+// PromoteDBaaSReplica detaches a read replica from its cluster and returns
+// the standalone cluster it becomes.
+func (c *Client) PromoteDBaaSReplica(clusterID, replicaID, projectID, location string) (*models.DB, error) {
+	log.Printf("[INFO] Promoting read replica %s of DBaaS cluster %s", replicaID, clusterID)
+	body, err := c.doDBaaSClusterRequest("PUT", clusterID+"/replica/"+replicaID+"/promote/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("promote read replica %s of DBaaS cluster %s failed: %v", replicaID, clusterID, err)
+	}
+	return decodeDBaaSCluster(body)
+}
+
+func (c *Client) DeleteDBaaSReplica(clusterID, replicaID, projectID, location string) error {
+	log.Printf("[INFO] Deleting read replica %s of DBaaS cluster %s", replicaID, clusterID)
+	if _, err := c.doDBaaSClusterRequest("DELETE", clusterID+"/replica/"+replicaID+"/", nil, projectID, location); err != nil {
+		return fmt.Errorf("delete read replica %s of DBaaS cluster %s failed: %v", replicaID, clusterID, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+func (c *Client) CreateDBaaSSnapshot(clusterID, name, projectID, location string) (*models.DBSnapshot, error) {
+	log.Printf("[INFO] Creating snapshot %s of DBaaS cluster %s", name, clusterID)
+	payload := models.DBSnapshotCreateRequest{Name: name}
+	body, err := c.doDBaaSClusterRequest("POST", clusterID+"/snapshot/", payload, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot of DBaaS cluster %s failed: %v", clusterID, err)
+	}
+	var response models.DBSnapshotResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return &response.Data, nil
+}
+
+func (c *Client) ListDBaaSSnapshots(clusterID, projectID, location string) ([]models.DBSnapshot, error) {
+	body, err := c.doDBaaSClusterRequest("GET", clusterID+"/snapshot/", nil, projectID, location)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots of DBaaS cluster %s failed: %v", clusterID, err)
+	}
+	var response models.DBSnapshotsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v\nresponse body: %s", err, string(body))
+	}
+	return response.Data, nil
+}
+
+// GetDBaaSSnapshot returns a snapshot of a cluster, nil when there is no
+// such snapshot.
+func (c *Client) GetDBaaSSnapshot(clusterID, snapshotID, projectID, location string) (*models.DBSnapshot, error) {
+	snapshots, err := c.ListDBaaSSnapshots(clusterID, projectID, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		if strconv.Itoa(snapshot.ID) == snapshotID {
+			return &snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) DeleteDBaaSSnapshot(clusterID, snapshotID, projectID, location string) error {
+	log.Printf("[INFO] Deleting snapshot %s of DBaaS cluster %s", snapshotID, clusterID)
+	if _, err := c.doDBaaSClusterRequest("DELETE", clusterID+"/snapshot/"+snapshotID+"/", nil, projectID, location); err != nil {
+		return fmt.Errorf("delete snapshot %s of DBaaS cluster %s failed: %v", snapshotID, clusterID, err)
+	}
+	return nil
+}
@@ -149,6 +149,33 @@ func (c *Client) GetKubernetesServiceInfo(kubernetesID string, location string,
 	return jsonRes, nil
 }
 
+func (c *Client) GetKubernetesKubeconfig(kubernetesID string, location string, project_id int) (map[string]interface{}, error) {
+	urlKubernetes := c.Api_endpoint + "kubernetes/kubeconfig/" + kubernetesID + "/"
+	req, err := http.NewRequest("GET", urlKubernetes, nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] CLIENT | KUBERNETES KUBECONFIG READ | URL: %s", urlKubernetes)
+	addParamsAndHeaders(req, c.Api_key, c.Auth_token, project_id, location)
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	err = CheckResponseStatus(response)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	resBody, _ := ioutil.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	err = json.Unmarshal(resBody, &jsonRes)
+	if err != nil {
+		log.Printf("[ERROR] CLIENT GET KUBERNETES KUBECONFIG | error when unmarshalling | %s", err)
+		return nil, err
+	}
+	return jsonRes, nil
+}
+
 func (c *Client) DeleteKubernetesService(kubernetesID string, location string, project_id int) error {
 	deleteURL := c.Api_endpoint + "kubernetes/" + kubernetesID
 	req, err := http.NewRequest("DELETE", deleteURL, nil)
@@ -267,6 +294,37 @@ func (c *Client) GetKubernetesNodePools(clusterID string, project_id int, locati
 	return jsonRes, nil
 }
 
+// GetKubernetesNodePoolDetail returns the size, plan and policies of a node pool.
+func (c *Client) GetKubernetesNodePoolDetail(nodePoolServiceID float64, project_id int, location string) (map[string]interface{}, error) {
+	serviceIDInString := strconv.FormatFloat(nodePoolServiceID, 'f', -1, 64)
+	url := c.Api_endpoint + "kubernetes/node-pool/" + serviceIDInString + "/"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	addParamsAndHeaders(req, c.Api_key, c.Auth_token, project_id, location)
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	err = CheckResponseStatus(response)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	resBody, _ := ioutil.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	err = json.Unmarshal(resBody, &jsonRes)
+
+	if err != nil {
+		log.Printf("[ERROR] CLIENT GET KUBERNETES NODE POOL DETAIL | error when unmarshalling")
+		return nil, err
+	}
+
+	return jsonRes, nil
+}
+
 func (c *Client) UpdateNodePoolCardinality(item *models.NodePoolResize, nodePoolServiceID float64, project_id int, location string) (map[string]interface{}, error) {
 	buf := bytes.Buffer{}
 	err := json.NewEncoder(&buf).Encode(item)
@@ -412,6 +470,75 @@ func (c *Client) UpdateNodePoolDetails(item *models.NodePoolUpdate, nodePoolServ
 	return jsonRes, nil
 }
 
+func (c *Client) UpgradeKubernetesVersion(item *models.KubernetesUpgrade, kubernetesID string, project_id int, location string) (map[string]interface{}, error) {
+	buf := bytes.Buffer{}
+	err := json.NewEncoder(&buf).Encode(item)
+	if err != nil {
+		return nil, err
+	}
+	urlKubernetes := c.Api_endpoint + "kubernetes/upgrade/" + kubernetesID + "/"
+	req, err := http.NewRequest("PUT", urlKubernetes, &buf)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] CLIENT | UPGRADE KUBERNETES CLUSTER %s TO VERSION %s", kubernetesID, item.Version)
+	addParamsAndHeaders(req, c.Api_key, c.Auth_token, project_id, location)
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	err = CheckResponseStatus(response)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	resBody, _ := ioutil.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	err = json.Unmarshal(resBody, &jsonRes)
+	if err != nil {
+		log.Printf("[ERROR] CLIENT UPGRADE KUBERNETES CLUSTER | error when unmarshalling")
+		return nil, err
+	}
+
+	return jsonRes, nil
+}
+
+func (c *Client) UpgradeNodePoolVersion(item *models.KubernetesUpgrade, nodePoolServiceID float64, project_id int, location string) (map[string]interface{}, error) {
+	buf := bytes.Buffer{}
+	err := json.NewEncoder(&buf).Encode(item)
+	if err != nil {
+		return nil, err
+	}
+	serviceIDInString := strconv.FormatFloat(nodePoolServiceID, 'f', -1, 64)
+	urlKubernetes := c.Api_endpoint + "kubernetes/upgrade-node-pool/" + serviceIDInString + "/"
+	req, err := http.NewRequest("PUT", urlKubernetes, &buf)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] CLIENT | UPGRADE KUBERNETES NODE POOL %s TO VERSION %s", serviceIDInString, item.Version)
+	addParamsAndHeaders(req, c.Api_key, c.Auth_token, project_id, location)
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	err = CheckResponseStatus(response)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	resBody, _ := ioutil.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	err = json.Unmarshal(resBody, &jsonRes)
+	if err != nil {
+		log.Printf("[ERROR] CLIENT UPGRADE KUBERNETES NODE POOL | error when unmarshalling")
+		return nil, err
+	}
+
+	return jsonRes, nil
+}
+
 func (c *Client) CheckNodePoolStatus(kubernetes_id string, project_id int, location string) (map[string]interface{}, error) {
 	urlNode := c.Api_endpoint + "kubernetes/node-pool-services/" + kubernetes_id
 	req, err := http.NewRequest("GET", urlNode, nil)
@@ -538,3 +665,67 @@ func (c *Client) DetachSecurityGroupsFromKubernetes(kubernetesClusterID string,
 
 	return jsonRes, nil
 }
+
+func (c *Client) GetAvailableKubernetesAddons(project_id int, location string) (map[string]interface{}, error) {
+	urlKubernetes := c.Api_endpoint + "kubernetes/addons/"
+	return c.doKubernetesAddonRequest("GET", urlKubernetes, nil, project_id, location)
+}
+
+func (c *Client) GetKubernetesAddons(kubernetesClusterID string, project_id int, location string) (map[string]interface{}, error) {
+	urlKubernetes := c.Api_endpoint + "kubernetes/" + kubernetesClusterID + "/addons/"
+	return c.doKubernetesAddonRequest("GET", urlKubernetes, nil, project_id, location)
+}
+
+func (c *Client) InstallKubernetesAddon(item *models.KubernetesAddon, kubernetesClusterID string, project_id int, location string) (map[string]interface{}, error) {
+	urlKubernetes := c.Api_endpoint + "kubernetes/" + kubernetesClusterID + "/addons/"
+	return c.doKubernetesAddonRequest("POST", urlKubernetes, item, project_id, location)
+}
+
+func (c *Client) UpdateKubernetesAddon(item *models.KubernetesAddon, kubernetesClusterID string, project_id int, location string) (map[string]interface{}, error) {
+	urlKubernetes := c.Api_endpoint + "kubernetes/" + kubernetesClusterID + "/addons/" + item.Name + "/"
+	return c.doKubernetesAddonRequest("PUT", urlKubernetes, item, project_id, location)
+}
+
+func (c *Client) UninstallKubernetesAddon(name string, kubernetesClusterID string, project_id int, location string) (map[string]interface{}, error) {
+	urlKubernetes := c.Api_endpoint + "kubernetes/" + kubernetesClusterID + "/addons/" + name + "/"
+	return c.doKubernetesAddonRequest("DELETE", urlKubernetes, nil, project_id, location)
+}
+
+func (c *Client) doKubernetesAddonRequest(method string, url string, item *models.KubernetesAddon, project_id int, location string) (map[string]interface{}, error) {
+	buf := bytes.Buffer{}
+	if item != nil {
+		err := json.NewEncoder(&buf).Encode(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, url, &buf)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] CLIENT | KUBERNETES ADDON %s | URL: %s", method, url)
+	addParamsAndHeaders(req, c.Api_key, c.Auth_token, project_id, location)
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if method == "DELETE" && response.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	// Installing an add-on may answer with 201 Created
+	if !(method == "POST" && response.StatusCode == http.StatusCreated) {
+		err = CheckResponseStatus(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resBody, _ := ioutil.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	err = json.Unmarshal(resBody, &jsonRes)
+	if err != nil {
+		log.Printf("[ERROR] CLIENT KUBERNETES ADDON %s | error when unmarshalling | %s", method, err)
+		return nil, err
+	}
+	return jsonRes, nil
+}
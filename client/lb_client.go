@@ -33,10 +33,6 @@ func (c *Client) NewLoadBalancer(item *models.LoadBalancerCreate, project_id str
 	}
 	UrlEndPoint := c.Api_endpoint + "appliances/load-balancers/"
 	log.Printf("[INFO] CLIENT NEWLOADBALANCER| BEFORE REQUEST")
-	buf, err = RemoveExtraKeysLoadBalancer(&buf)
-	if err != nil {
-		return nil, err
-	}
 	req, err := http.NewRequest("POST", UrlEndPoint, &buf)
 	if err != nil {
 		return nil, err
@@ -227,12 +223,6 @@ func (c *Client) LoadBalancerBackendUpdate(item *models.LoadBalancerCreate, lbId
 		return nil, err
 	}
 
-	buf, err = RemoveExtraKeysLoadBalancer(&buf)
-	if err != nil {
-		log.Printf("[ERROR] LoadBalancerBackendUpdate | RemoveExtraKeysLoadBalancer | %s", err)
-		return nil, err
-	}
-
 	log.Printf("================LOAD BALANCER UPDATE API INFO==================, %s, %s", UrlEndPoint, &buf)
 	req, err := http.NewRequest("PUT", UrlEndPoint, &buf)
 	if err != nil {
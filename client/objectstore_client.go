@@ -206,3 +206,112 @@ func (client *Client) DeleteBucket(bucket_name string, location string, project_
 
 	return nil
 }
+
+func (client *Client) CreateAccessKey(tag string, location string, project_id string) (*models.AccessKey, error) {
+	log.Printf("[INFO] CREATE ACCESS KEY")
+	payload_buffer := bytes.Buffer{}
+	err := json.NewEncoder(&payload_buffer).Encode(models.AccessKeyPayload{Tag: tag})
+	if err != nil {
+		return nil, err
+	}
+	AccessKeyCreateUrl := client.Api_endpoint + "storage/core/users/"
+	create_request, err := http.NewRequest("POST", AccessKeyCreateUrl, &payload_buffer)
+	if err != nil {
+		return nil, err
+	}
+	create_request = client.setParamsAndHeaders(create_request, location, project_id)
+	response, err := client.HttpClient.Do(create_request)
+	if err != nil {
+		return nil, err
+	}
+	err = CheckResponseStatus(response)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	res := models.ResponseAccessKey{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		log.Printf("[INFO] inside CLIENT CREATE ACCESS KEY | error while unmarshlling")
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+func (client *Client) GetAccessKeys(location string, project_id string) (*models.ResponseAccessKeys, error) {
+	urlGetAccessKeys := client.Api_endpoint + "storage/core/list/users/"
+	readrequest, err := http.NewRequest("GET", urlGetAccessKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] CLIENT GET ACCESS KEYS")
+	readrequest = client.setParamsAndHeaders(readrequest, location, project_id)
+	response, err := client.HttpClient.Do(readrequest)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		respBody := new(bytes.Buffer)
+		_, err := respBody.ReadFrom(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("got a non 200 status code: %v", response.StatusCode)
+		}
+		return nil, fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, respBody.String())
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	res := models.ResponseAccessKeys{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		log.Printf("[INFO] inside CLIENT GET ACCESS KEYS | error while unmarshlling")
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (client *Client) GetAccessKey(access_key string, location string, project_id string) (*models.AccessKey, error) {
+	accessKeys, err := client.GetAccessKeys(location, project_id)
+	if err != nil {
+		return nil, err
+	}
+	for _, accessKey := range accessKeys.Data {
+		if accessKey.AccessKey == access_key {
+			return &accessKey, nil
+		}
+	}
+	return nil, fmt.Errorf("access key %s not found", access_key)
+}
+
+func (client *Client) DeleteAccessKey(access_key_id string, access_key string, location string, project_id string) error {
+	urlDeleteAccessKey := client.Api_endpoint + "storage/core/users/"
+	deleterequest, err := http.NewRequest("DELETE", urlDeleteAccessKey, nil)
+	if err != nil {
+		return err
+	}
+	deleterequest = client.setParamsAndHeaders(deleterequest, location, project_id)
+	params := deleterequest.URL.Query()
+	params.Add("access_key", access_key)
+	params.Add("access_key_id", access_key_id)
+	deleterequest.URL.RawQuery = params.Encode()
+
+	response, err := client.HttpClient.Do(deleterequest)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusOK {
+		respBody := new(bytes.Buffer)
+		_, err := respBody.ReadFrom(response.Body)
+		if err != nil {
+			return fmt.Errorf("got a non 200 status code: %v", response.StatusCode)
+		}
+		return fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, respBody.String())
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceScalerGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceReadScalerGroups,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID associated with the scaler groups",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Location of the scaler groups",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return scaler groups whose name matches this regular expression",
+			},
+			"provision_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Running", "Stopped"}, false),
+				Description:  "Only return scaler groups in this provision status",
+			},
+			"plan_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return scaler groups using this plan",
+			},
+
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the matching scaler groups",
+			},
+			"scaler_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching scaler groups",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":               {Type: schema.TypeString, Computed: true},
+						"name":             {Type: schema.TypeString, Computed: true},
+						"provision_status": {Type: schema.TypeString, Computed: true},
+						"plan_name":        {Type: schema.TypeString, Computed: true},
+						"vm_image_name":    {Type: schema.TypeString, Computed: true},
+						"min_nodes":        {Type: schema.TypeInt, Computed: true},
+						"max_nodes":        {Type: schema.TypeInt, Computed: true},
+						"desired":          {Type: schema.TypeInt, Computed: true},
+						"current_nodes":    {Type: schema.TypeInt, Computed: true},
+						"vpc": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name":       {Type: schema.TypeString, Computed: true},
+									"network_id": {Type: schema.TypeInt, Computed: true},
+									"ipv4_cidr":  {Type: schema.TypeString, Computed: true},
+								},
+							},
+						},
+						"security_group_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadScalerGroups(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	groups, err := apiClient.ListScalerGroups(projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	status := d.Get("provision_status").(string)
+	planName := d.Get("plan_name").(string)
+
+	ids := []string{}
+	scalerGroups := []map[string]interface{}{}
+	for _, group := range groups {
+		if nameRegex != nil && !nameRegex.MatchString(group.Name) {
+			continue
+		}
+		if status != "" && group.ProvisionStatus != status {
+			continue
+		}
+		if planName != "" && group.PlanName != planName {
+			continue
+		}
+
+		id := strconv.Itoa(group.ID)
+		scalerGroup, err := flattenListedScalerGroup(apiClient, group, projectID, location)
+		if err != nil {
+			return diag.Errorf("error reading scaler group %s: %s", id, err)
+		}
+		ids = append(ids, id)
+		scalerGroups = append(scalerGroups, scalerGroup)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, location))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("scaler_groups", scalerGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func flattenListedScalerGroup(apiClient *client.Client, group models.ScalerGroupGetDetail, projectID, location string) (map[string]interface{}, error) {
+	id := strconv.Itoa(group.ID)
+
+	attachedVPCs, err := apiClient.GetAttachedVPCsForScalerGroup(id, projectID, location)
+	if err != nil {
+		return nil, err
+	}
+	vpcs := make([]map[string]interface{}, 0, len(attachedVPCs))
+	for _, vpc := range attachedVPCs {
+		vpcs = append(vpcs, map[string]interface{}{
+			"name":       vpc.Name,
+			"network_id": vpc.NetworkID,
+			"ipv4_cidr":  vpc.IPv4CIDR,
+		})
+	}
+
+	securityGroups, err := apiClient.GetScalerGroupSecurityGroups(id, projectID, location)
+	if err != nil {
+		return nil, err
+	}
+	securityGroupIDs := make([]int, 0, len(securityGroups))
+	for _, sg := range securityGroups {
+		securityGroupIDs = append(securityGroupIDs, sg.ID)
+	}
+
+	return map[string]interface{}{
+		"id":                 id,
+		"name":               group.Name,
+		"provision_status":   group.ProvisionStatus,
+		"plan_name":          group.PlanName,
+		"vm_image_name":      group.VMImageName,
+		"min_nodes":          group.MinNodes,
+		"max_nodes":          group.MaxNodes,
+		"desired":            group.Desired,
+		"current_nodes":      group.Running,
+		"vpc":                vpcs,
+		"security_group_ids": securityGroupIDs,
+	}, nil
+}
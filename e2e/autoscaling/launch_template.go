@@ -0,0 +1,266 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scalerLaunchConfig holds the settings new nodes of a scaler group are launched
+// with, taken either from the launch_template block or the top level attributes.
+type scalerLaunchConfig struct {
+	VMImageName          string
+	PlanName             string
+	IsEncryptionEnabled  bool
+	EncryptionPassphrase string
+	IsPublicIPRequired   bool
+	SecurityGroupIDs     []int
+}
+
+func launchTemplateSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vm_image_name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppressImageNameSuffix,
+			Description:      "Name of the saved image the nodes are launched from.",
+		},
+		"plan_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Plan of the nodes, e.g. C3.8GB.",
+		},
+		"is_encryption_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Enable encryption on the nodes.",
+		},
+		"encryption_passphrase": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Passphrase for encryption (if enabled).",
+		},
+		"is_public_ip_required": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to assign a public IP to nodes. Needs a VPC attached to be turned off.",
+		},
+		"security_group_ids": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Description: "Security groups attached to the nodes. Defaults to the default security group of the project.",
+		},
+	}
+}
+
+func suppressImageNameSuffix(k, old, new string, d *schema.ResourceData) bool {
+	trimmedOld := trimImageName(old)
+	trimmedNew := trimImageName(new)
+
+	log.Printf("[DEBUG] DiffSuppressFunc: old=%s → %s, new=%s → %s", old, trimmedOld, new, trimmedNew)
+
+	return trimmedOld == trimmedNew
+}
+
+// suppressWithLaunchTemplate hides diffs of top level launch settings that the
+// launch_template block takes over when it is set.
+func suppressWithLaunchTemplate(k, old, new string, d *schema.ResourceData) bool {
+	return len(d.Get("launch_template").([]interface{})) > 0
+}
+
+func trimImageName(name string) string {
+	if idx := strings.Index(name, "_"); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
+
+func expandScalerLaunchConfig(d *schema.ResourceData) scalerLaunchConfig {
+	return launchConfig(d.Get("launch_template").([]interface{}), d.Get)
+}
+
+// scalerLaunchConfigChange returns the launch settings before and after the
+// planned change.
+func scalerLaunchConfigChange(d *schema.ResourceData) (scalerLaunchConfig, scalerLaunchConfig) {
+	oldTemplate, newTemplate := d.GetChange("launch_template")
+	oldValue := func(key string) interface{} {
+		o, _ := d.GetChange(key)
+		return o
+	}
+	return launchConfig(oldTemplate.([]interface{}), oldValue), launchConfig(newTemplate.([]interface{}), d.Get)
+}
+
+func launchConfig(template []interface{}, get func(string) interface{}) scalerLaunchConfig {
+	if len(template) > 0 && template[0] != nil {
+		t := template[0].(map[string]interface{})
+		return scalerLaunchConfig{
+			VMImageName:          t["vm_image_name"].(string),
+			PlanName:             t["plan_name"].(string),
+			IsEncryptionEnabled:  t["is_encryption_enabled"].(bool),
+			EncryptionPassphrase: t["encryption_passphrase"].(string),
+			IsPublicIPRequired:   t["is_public_ip_required"].(bool),
+			SecurityGroupIDs:     expandIntList(t["security_group_ids"].([]interface{})),
+		}
+	}
+	return scalerLaunchConfig{
+		VMImageName:          get("vm_image_name").(string),
+		PlanName:             get("plan_name").(string),
+		IsEncryptionEnabled:  get("is_encryption_enabled").(bool),
+		EncryptionPassphrase: get("encryption_passphrase").(string),
+		IsPublicIPRequired:   get("is_public_ip_required").(bool),
+		SecurityGroupIDs:     expandIntList(get("security_group_ids").([]interface{})),
+	}
+}
+
+// rolledBy reports whether moving to the new launch settings needs the nodes of
+// the group to be replaced. Public IP and security groups can be changed on the
+// running nodes.
+func (c scalerLaunchConfig) rolledBy(n scalerLaunchConfig) bool {
+	return trimImageName(c.VMImageName) != trimImageName(n.VMImageName) ||
+		c.PlanName != n.PlanName ||
+		c.IsEncryptionEnabled != n.IsEncryptionEnabled ||
+		c.EncryptionPassphrase != n.EncryptionPassphrase
+}
+
+// checkScalerGroupName fails when a scaler group with the name already exists,
+// as E2E doesn't tell groups of the same name apart.
+func checkScalerGroupName(apiClient *client.Client, name string, projectID, location string) error {
+	groups, err := apiClient.ListScalerGroups(projectID, location)
+	if err != nil {
+		return fmt.Errorf("failed to list scaler groups: %v", err)
+	}
+	for _, group := range groups {
+		if group.Name == name {
+			return fmt.Errorf("a scaler group named %q already exists in %s (ID %d), scaler group names must be unique", name, location, group.ID)
+		}
+	}
+	return nil
+}
+
+// rollScalerGroupNodes replaces the nodes of a scaler group by nodes launched with
+// its current launch settings, removing old nodes through the group so the
+// autoscaler neither relaunches them nor picks other nodes to scale in. In every
+// batch desired is raised by up to max_surge and, once the new nodes run, as many
+// old nodes are removed with desired lowered back. Up to max_unavailable more old
+// nodes are removed with desired kept, for the group to replace them. The group
+// keeps between desired - max_unavailable and desired + max_surge nodes, and
+// settles before every next step. The policies of the group stay in place.
+func rollScalerGroupNodes(ctx context.Context, d *schema.ResourceData, apiClient *client.Client, id string, target int, projectID, location string) error {
+	surge, unavailable := 1, 0
+	if v, ok := d.GetOk("rolling_update"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		rolling := v.([]interface{})[0].(map[string]interface{})
+		surge = rolling["max_surge"].(int)
+		unavailable = rolling["max_unavailable"].(int)
+	}
+
+	group, err := apiClient.GetScalerGroup(id, projectID, location)
+	if err != nil {
+		return fmt.Errorf("failed to read scaler group: %v", err)
+	}
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid scaler group ID: %v", err)
+	}
+	surge = min(surge, group.MaxNodes-target)
+	if surge < 1 && unavailable < 1 {
+		return fmt.Errorf("replacing the nodes of scaler group %s needs room below max_nodes (%d) for max_surge, or max_unavailable of at least 1", id, group.MaxNodes)
+	}
+	oldNodes := make([]int, 0, len(group.Nodes))
+	for _, node := range group.Nodes {
+		oldNodes = append(oldNodes, node.ID)
+	}
+
+	for len(oldNodes) > 0 {
+		up := min(surge, len(oldNodes))
+		if up > 0 {
+			log.Printf("[INFO] Rolling scaler group %s: launching %d new node(s), %d old node(s) left", id, up, len(oldNodes))
+			if err := apiClient.UpdateDesiredNodeCount(intID, target+up, projectID, location); err != nil {
+				return fmt.Errorf("failed to scale up scaler group %s: %v", id, err)
+			}
+			if err := waitForScalerGroupNodes(ctx, apiClient, id, target+up, nil, projectID, location); err != nil {
+				return err
+			}
+			if err := removeScalerGroupNodes(ctx, apiClient, id, oldNodes[:up], true, target, projectID, location); err != nil {
+				return err
+			}
+			oldNodes = oldNodes[up:]
+		}
+
+		if replace := min(unavailable, len(oldNodes)); replace > 0 {
+			log.Printf("[INFO] Rolling scaler group %s: replacing %d old node(s), %d left", id, replace, len(oldNodes)-replace)
+			if err := removeScalerGroupNodes(ctx, apiClient, id, oldNodes[:replace], false, target, projectID, location); err != nil {
+				return err
+			}
+			oldNodes = oldNodes[replace:]
+		}
+	}
+	return nil
+}
+
+// removeScalerGroupNodes removes the given nodes through the scaler group, either
+// lowering desired by as many or having the group replace them, and waits for the
+// group to run count nodes without them.
+func removeScalerGroupNodes(ctx context.Context, apiClient *client.Client, id string, nodeIDs []int, decrementDesired bool, count int, projectID, location string) error {
+	if err := apiClient.RemoveScalerGroupNodes(id, &models.RemoveScalerGroupNodesRequest{
+		NodeIDs:          nodeIDs,
+		DecrementDesired: decrementDesired,
+	}, projectID, location); err != nil {
+		return fmt.Errorf("failed to remove nodes %v of scaler group %s: %v", nodeIDs, id, err)
+	}
+	return waitForScalerGroupNodes(ctx, apiClient, id, count, nodeIDs, projectID, location)
+}
+
+// waitForScalerGroupNodes waits until the scaler group is Running with the given
+// number of nodes up, none of them one of the removed nodes.
+func waitForScalerGroupNodes(ctx context.Context, apiClient *client.Client, id string, count int, removed []int, projectID, location string) error {
+	for {
+		group, err := apiClient.GetScalerGroup(id, projectID, location)
+		if err != nil {
+			return fmt.Errorf("failed to fetch scaler group status: %w", err)
+		}
+		if group.ProvisionStatus == "Running" && group.Running == count && !hasScalerGroupNode(group.Nodes, removed) {
+			return nil
+		}
+		log.Printf("[INFO] Scaler group %s is %s with %d of %d node(s) running", id, group.ProvisionStatus, group.Running, count)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for scaler group %s to run %d node(s), current: %d", id, count, group.Running)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+func hasScalerGroupNode(nodes []models.ScaleGroupNode, ids []int) bool {
+	for _, node := range nodes {
+		for _, id := range ids {
+			if node.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalIntLists(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package autoscaling
+
+import (
+	"fmt"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/node"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func lifecycleHooksSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"start_script": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Script run on every node when it is launched. Changes only apply to nodes launched afterwards.",
+		},
+		"drain_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, 3600),
+			Description:  "Seconds a node is given to finish its work before it is removed on scale-in.",
+		},
+	}
+}
+
+func healthCheckSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether unhealthy nodes are replaced.",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Status",
+			ValidateFunc: validation.StringInSlice([]string{"Status", "TCP"}, false),
+			Description:  "Status checks the node state, TCP connects to port on the node.",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port connected to by TCP checks.",
+		},
+		"interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      60,
+			ValidateFunc: validation.IntBetween(10, 3600),
+			Description:  "Seconds between two checks.",
+		},
+		"unhealthy_threshold": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      3,
+			ValidateFunc: validation.IntBetween(1, 10),
+			Description:  "Consecutive failed checks after which a node is replaced.",
+		},
+		"grace_period": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      300,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Seconds after launch before a node is checked, giving the start script time to finish.",
+		},
+	}
+}
+
+func expandScalerGroupLifecycleHooks(d *schema.ResourceData) *models.ScalerGroupLifecycleHooks {
+	v, ok := d.GetOk("lifecycle_hooks")
+	if !ok || v.([]interface{})[0] == nil {
+		return nil
+	}
+	hooks := v.([]interface{})[0].(map[string]interface{})
+	return &models.ScalerGroupLifecycleHooks{
+		StartScripts: node.GetStartScripts(hooks["start_script"].(string)),
+		DrainTimeout: hooks["drain_timeout"].(int),
+	}
+}
+
+func expandScalerGroupHealthCheck(d *schema.ResourceData) *models.ScalerGroupHealthCheck {
+	v, ok := d.GetOk("health_check")
+	if !ok || v.([]interface{})[0] == nil {
+		return nil
+	}
+	check := v.([]interface{})[0].(map[string]interface{})
+	return &models.ScalerGroupHealthCheck{
+		Enabled:            check["enabled"].(bool),
+		Type:               check["type"].(string),
+		Port:               check["port"].(int),
+		Interval:           check["interval"].(int),
+		UnhealthyThreshold: check["unhealthy_threshold"].(int),
+		GracePeriod:        check["grace_period"].(int),
+	}
+}
+
+func flattenScalerGroupLifecycleHooks(hooks *models.ScalerGroupLifecycleHooks) []interface{} {
+	var startScript string
+	if len(hooks.StartScripts) > 0 {
+		startScript, _ = hooks.StartScripts[0].(string)
+	}
+	return []interface{}{map[string]interface{}{
+		"start_script":  startScript,
+		"drain_timeout": hooks.DrainTimeout,
+	}}
+}
+
+func flattenScalerGroupHealthCheck(check *models.ScalerGroupHealthCheck) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"enabled":             check.Enabled,
+		"type":                check.Type,
+		"port":                check.Port,
+		"interval":            check.Interval,
+		"unhealthy_threshold": check.UnhealthyThreshold,
+		"grace_period":        check.GracePeriod,
+	}}
+}
+
+func validateScalerGroupHealthCheck(diff *schema.ResourceDiff) error {
+	v, ok := diff.GetOk("health_check")
+	if !ok || v.([]interface{})[0] == nil {
+		return nil
+	}
+	check := v.([]interface{})[0].(map[string]interface{})
+	if check["type"] == "TCP" && check["port"].(int) == 0 {
+		return fmt.Errorf("health_check: port is required for TCP checks")
+	}
+	if check["type"] != "TCP" && check["port"].(int) != 0 {
+		return fmt.Errorf("health_check: port can only be set for TCP checks")
+	}
+	return nil
+}
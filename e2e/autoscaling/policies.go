@@ -0,0 +1,258 @@
+package autoscaling
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	scalingMetrics   = []string{"CPU", "Memory", "Custom"}
+	scalingOperators = []string{">", ">=", "<", "<="}
+	numericString    = validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "must be a whole number")
+)
+
+// scalingRuleSchema holds the attributes shared by step and target tracking
+// policies: the metric they watch and how often it is evaluated.
+func scalingRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"metric": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "CPU",
+			ValidateFunc: validation.StringInSlice(scalingMetrics, false),
+			Description:  "Metric the policy watches: CPU, Memory or Custom.",
+		},
+		"custom_metric_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[A-Z0-9]([_]?[A-Z0-9])+$`),
+				"Custom metric name should be at least 2 characters long with upper case characters, numbers and underscore and must start and end with characters or numbers.",
+			),
+			Description: "Name of the custom metric. Required when metric is Custom.",
+		},
+		"period_number": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      3,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of periods the threshold has to be crossed for.",
+		},
+		"period_seconds": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Length of a period in seconds.",
+		},
+		"cooldown": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      150,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Seconds to wait after a scaling action before evaluating again.",
+		},
+	}
+}
+
+func stepPolicySchema() map[string]*schema.Schema {
+	s := scalingRuleSchema()
+	s["adjust"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+		ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+			if val.(int) == 0 {
+				errs = append(errs, fmt.Errorf("%q must not be 0", key))
+			}
+			return
+		},
+		Description: "Nodes to add (positive) or remove (negative) when the policy triggers.",
+	}
+	s["operator"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringInSlice(scalingOperators, false),
+		Description:  "Comparison of the metric with value: >, >=, < or <=.",
+	}
+	s["value"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Threshold of the metric. A percentage for CPU and Memory.",
+	}
+	return s
+}
+
+func targetTrackingPolicySchema() map[string]*schema.Schema {
+	s := scalingRuleSchema()
+	s["target_value"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "Value the metric is kept at. A percentage for CPU and Memory.",
+	}
+	s["tolerance"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      10,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "How far the metric can move away from target_value before the group scales.",
+	}
+	s["adjust"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "Nodes added or removed per scaling action.",
+	}
+	return s
+}
+
+// scalerGroupPolicies is the scaling configuration sent to the API.
+type scalerGroupPolicies struct {
+	PolicyType      string
+	CustomParamName string
+	Policies        []models.ElasticPolicy
+}
+
+// expandScalerGroupPolicies maps whichever of policy, step_policy and
+// target_tracking_policy is set to elastic policies.
+func expandScalerGroupPolicies(d *schema.ResourceData) scalerGroupPolicies {
+	result := scalerGroupPolicies{Policies: []models.ElasticPolicy{}}
+
+	if v, ok := d.GetOk("target_tracking_policy"); ok {
+		t := v.([]interface{})[0].(map[string]interface{})
+		target := t["target_value"].(int)
+		tolerance := t["tolerance"].(int)
+		adjust := t["adjust"].(int)
+		result.setMetric(t)
+		result.Policies = append(result.Policies,
+			elasticPolicy(t, adjust, ">", target+tolerance),
+			elasticPolicy(t, -adjust, "<", target-tolerance),
+		)
+		return result
+	}
+
+	if v, ok := d.GetOk("step_policy"); ok {
+		for _, p := range v.([]interface{}) {
+			step := p.(map[string]interface{})
+			result.setMetric(step)
+			result.Policies = append(result.Policies, elasticPolicy(step, step["adjust"].(int), step["operator"].(string), step["value"].(int)))
+		}
+		return result
+	}
+
+	for _, p := range d.Get("policy").([]interface{}) {
+		pMap := p.(map[string]interface{})
+		result.Policies = append(result.Policies, models.ElasticPolicy{
+			Type:          pMap["type"].(string),
+			Adjust:        pMap["adjust"].(int),
+			Parameter:     pMap["parameter"].(string),
+			Operator:      pMap["operator"].(string),
+			Value:         pMap["value"].(string),
+			PeriodNumber:  pMap["period_number"].(string),
+			PeriodSeconds: pMap["period_seconds"].(string),
+			Cooldown:      pMap["cooldown"].(string),
+		})
+	}
+	result.PolicyType = d.Get("policy_type").(string)
+	return result
+}
+
+func (p *scalerGroupPolicies) setMetric(rule map[string]interface{}) {
+	p.PolicyType = "Default"
+	if rule["metric"] == "Custom" {
+		p.PolicyType = "Custom"
+		p.CustomParamName = rule["custom_metric_name"].(string)
+	}
+}
+
+func elasticPolicy(rule map[string]interface{}, adjust int, operator string, value int) models.ElasticPolicy {
+	return models.ElasticPolicy{
+		Type:          "CHANGE",
+		Adjust:        adjust,
+		Parameter:     rule["metric"].(string),
+		Operator:      operator,
+		Value:         strconv.Itoa(value),
+		PeriodNumber:  strconv.Itoa(rule["period_number"].(int)),
+		PeriodSeconds: strconv.Itoa(rule["period_seconds"].(int)),
+		Cooldown:      strconv.Itoa(rule["cooldown"].(int)),
+	}
+}
+
+// validateScalerGroupPolicies checks the typed policies against each other at
+// plan time: a group watches a single metric, scale up rules trigger above
+// the metric and scale down rules below it, and percentages stay within 0-100.
+func validateScalerGroupPolicies(diff *schema.ResourceDiff) error {
+	if v, ok := diff.GetOk("target_tracking_policy"); ok {
+		t := v.([]interface{})[0].(map[string]interface{})
+		if err := validateScalingMetric(t); err != nil {
+			return fmt.Errorf("target_tracking_policy: %v", err)
+		}
+		target := t["target_value"].(int)
+		tolerance := t["tolerance"].(int)
+		if target-tolerance < 0 {
+			return fmt.Errorf("target_tracking_policy: target_value (%d) minus tolerance (%d) must not be below 0", target, tolerance)
+		}
+		if t["metric"] != "Custom" && target+tolerance > 100 {
+			return fmt.Errorf("target_tracking_policy: target_value (%d) plus tolerance (%d) must not exceed 100%% of %s", target, tolerance, t["metric"])
+		}
+		return nil
+	}
+
+	steps := diff.Get("step_policy").([]interface{})
+	var metric string
+	lowestUp, highestDown := -1, -1
+	for i, p := range steps {
+		step := p.(map[string]interface{})
+		if err := validateScalingMetric(step); err != nil {
+			return fmt.Errorf("step_policy %d: %v", i, err)
+		}
+		name := step["metric"].(string) + step["custom_metric_name"].(string)
+		if metric != "" && name != metric {
+			return fmt.Errorf("step_policy %d: all step policies must watch the same metric", i)
+		}
+		metric = name
+
+		value := step["value"].(int)
+		if step["metric"] != "Custom" && value > 100 {
+			return fmt.Errorf("step_policy %d: value (%d) must not exceed 100%% of %s", i, value, step["metric"])
+		}
+		operator := step["operator"].(string)
+		if step["adjust"].(int) > 0 {
+			if operator != ">" && operator != ">=" {
+				return fmt.Errorf("step_policy %d: a policy adding nodes must use > or >=, got %s", i, operator)
+			}
+			if lowestUp == -1 || value < lowestUp {
+				lowestUp = value
+			}
+		} else {
+			if operator != "<" && operator != "<=" {
+				return fmt.Errorf("step_policy %d: a policy removing nodes must use < or <=, got %s", i, operator)
+			}
+			if value > highestDown {
+				highestDown = value
+			}
+		}
+	}
+	if lowestUp != -1 && highestDown != -1 && highestDown >= lowestUp {
+		return fmt.Errorf("step_policy: scale down threshold (%d) must be below scale up threshold (%d)", highestDown, lowestUp)
+	}
+	return nil
+}
+
+func validateScalingMetric(rule map[string]interface{}) error {
+	custom := rule["custom_metric_name"].(string)
+	if rule["metric"] == "Custom" && custom == "" {
+		return fmt.Errorf("custom_metric_name is required when metric is Custom")
+	}
+	if rule["metric"] != "Custom" && custom != "" {
+		return fmt.Errorf("custom_metric_name can only be set when metric is Custom")
+	}
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/node"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -37,9 +39,11 @@ func ResourceScalerGroup() *schema.Resource {
 				ForceNew: true,
 			},
 			"plan_name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"launch_template"},
 			},
 			"plan_id": {
 				Type:        schema.TypeString,
@@ -58,23 +62,49 @@ func ResourceScalerGroup() *schema.Resource {
 			},
 
 			"vm_image_name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					trim := func(name string) string {
-						if idx := strings.Index(name, "_"); idx != -1 {
-							return name[:idx]
-						}
-						return name
-					}
-					trimmedOld := trim(old)
-					trimmedNew := trim(new)
-
-					log.Printf("[DEBUG] DiffSuppressFunc: old=%s → %s, new=%s → %s", old, trimmedOld, new, trimmedNew)
-
-					return trimmedOld == trimmedNew
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"vm_image_name", "launch_template"},
+				RequiredWith:     []string{"vm_image_name", "plan_name"},
+				DiffSuppressFunc: suppressImageNameSuffix,
+			},
+			"launch_template": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: launchTemplateSchema()},
+				Description: "Launch settings of the nodes. Changing the image, plan or encryption replaces the nodes within the scaler group.",
+			},
+			"launch_template_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the launch template, incremented on every change to it.",
+			},
+			"rolling_update": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_surge": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of nodes that can be launched above desired while replacing nodes.",
+						},
+						"max_unavailable": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of nodes that can be missing below desired while replacing nodes.",
+						},
+					},
 				},
+				Description: "How nodes are replaced when the image, plan or encryption of the launch template changes.",
 			},
 			"vm_image_id": {
 				Type:     schema.TypeString,
@@ -92,19 +122,21 @@ func ResourceScalerGroup() *schema.Resource {
 				Description: "The Security Group ID to attach to the scaler group. If not provided, a default will be fetched from the API.",
 			},
 			"security_group_ids": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeInt},
-				Description: "The list of Security Group IDs currently attached to the scaler group. Used for updates.",
+				Type:             schema.TypeList,
+				Optional:         true,
+				Computed:         true,
+				Elem:             &schema.Schema{Type: schema.TypeInt},
+				DiffSuppressFunc: suppressWithLaunchTemplate,
+				Description:      "The list of Security Group IDs currently attached to the scaler group. Used for updates.",
 			},
 
 			"is_encryption_enabled": {
-				Type:     schema.TypeBool,
-				Required: true,
-
-				ForceNew:    true,
-				Description: "Enable encryption for the scaler group. Defaults to false.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"launch_template"},
+				Description:   "Enable encryption for the scaler group. Defaults to false.",
 			},
 			"encryption_passphrase": {
 				Type:        schema.TypeString,
@@ -114,10 +146,11 @@ func ResourceScalerGroup() *schema.Resource {
 				Description: "Passphrase for encryption (if enabled). Defaults to empty string.",
 			},
 			"is_public_ip_required": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: "Whether to assign a public IP to nodes. Can only be updated when the scaler group is stopped and a VPC is attached.",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          true,
+				DiffSuppressFunc: suppressWithLaunchTemplate,
+				Description:      "Whether to assign a public IP to nodes. Can only be updated when the scaler group is stopped and a VPC is attached.",
 			},
 
 			"provision_status": {
@@ -147,10 +180,58 @@ func ResourceScalerGroup() *schema.Resource {
 			"desired": {
 				Type:     schema.TypeInt,
 				Required: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// Once created, an unmanaged desired count belongs to the autoscaler
+					return d.Id() != "" && !d.Get("manage_desired").(bool)
+				},
+				Description: "Desired node count. Only used at creation when manage_desired is false.",
+			},
+			"manage_desired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether Terraform keeps desired in sync with the configuration. Set to false to let the scaling policies own the desired count after creation.",
+			},
+			"current_nodes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of nodes currently running in the scaler group.",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Nodes currently in the scaler group.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_ips": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"policy_type": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Policy type of the legacy policy blocks. Set from the metric when step_policy or target_tracking_policy is used.",
 			},
 			"vpc": {
 				Type:     schema.TypeList,
@@ -208,22 +289,53 @@ func ResourceScalerGroup() *schema.Resource {
 			},
 
 			"policy": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:          schema.TypeList,
+				Optional:      true,
+				Deprecated:    "Use step_policy or target_tracking_policy instead",
+				ConflictsWith: []string{"step_policy", "target_tracking_policy"},
 
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type":           {Type: schema.TypeString, Required: true},
 						"adjust":         {Type: schema.TypeInt, Required: true},
 						"parameter":      {Type: schema.TypeString, Required: true},
-						"operator":       {Type: schema.TypeString, Required: true},
-						"value":          {Type: schema.TypeString, Required: true},
-						"period_number":  {Type: schema.TypeString, Required: true},
-						"period_seconds": {Type: schema.TypeString, Required: true},
-						"cooldown":       {Type: schema.TypeString, Required: true},
+						"operator":       {Type: schema.TypeString, Required: true, ValidateFunc: validation.StringInSlice(scalingOperators, false)},
+						"value":          {Type: schema.TypeString, Required: true, ValidateFunc: numericString},
+						"period_number":  {Type: schema.TypeString, Required: true, ValidateFunc: numericString},
+						"period_seconds": {Type: schema.TypeString, Required: true, ValidateFunc: numericString},
+						"cooldown":       {Type: schema.TypeString, Required: true, ValidateFunc: numericString},
 					},
 				},
 			},
+			"step_policy": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"policy", "target_tracking_policy"},
+				Elem:          &schema.Resource{Schema: stepPolicySchema()},
+				Description:   "Policies adding or removing nodes when a metric crosses a threshold.",
+			},
+			"target_tracking_policy": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"policy", "step_policy"},
+				Elem:          &schema.Resource{Schema: targetTrackingPolicySchema()},
+				Description:   "Keeps a metric around a target value, e.g. CPU at 60%.",
+			},
+			"lifecycle_hooks": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: lifecycleHooksSchema()},
+				Description: "Actions taken when nodes are launched and removed.",
+			},
+			"health_check": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: healthCheckSchema()},
+				Description: "Health checks replacing nodes that fail them.",
+			},
 			"scheduled_policy": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -246,6 +358,28 @@ func ResourceScalerGroup() *schema.Resource {
 			desired := diff.Get("desired").(int)
 			max := diff.Get("max_nodes").(int)
 
+			if min > max {
+				return fmt.Errorf("min_nodes (%d) cannot be greater than max_nodes (%d)", min, max)
+			}
+			if err := validateScalerGroupPolicies(diff); err != nil {
+				return err
+			}
+			if err := validateScalerGroupHealthCheck(diff); err != nil {
+				return err
+			}
+
+			if diff.Id() != "" && diff.HasChange("launch_template") {
+				version, _ := diff.GetChange("launch_template_version")
+				if err := diff.SetNew("launch_template_version", version.(int)+1); err != nil {
+					return err
+				}
+			}
+
+			// desired in state follows the autoscaler and is clamped on update
+			if diff.Id() != "" && !diff.Get("manage_desired").(bool) {
+				return nil
+			}
+
 			if min > desired {
 				return fmt.Errorf("min_nodes (%d) cannot be greater than desired (%d)", min, desired)
 			}
@@ -260,6 +394,10 @@ func ResourceScalerGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: node.CustomImportStateFunc,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
 	}
 }
 
@@ -269,7 +407,8 @@ func resourceCreateScalerGroup(ctx context.Context, d *schema.ResourceData, m in
 	apiClient := m.(*client.Client)
 	projectID := d.Get("project_id").(string)
 	location := d.Get("location").(string)
-	imageName := d.Get("vm_image_name").(string)
+	launch := expandScalerLaunchConfig(d)
+	imageName := launch.VMImageName
 
 	savedImage, err := apiClient.GetSavedImageByName(imageName, projectID, location)
 	if err != nil {
@@ -289,6 +428,12 @@ func resourceCreateScalerGroup(ctx context.Context, d *schema.ResourceData, m in
 	if v, ok := d.GetOk("my_account_sg_id"); ok {
 		sgID = v.(int)
 		log.Printf("[INFO] Using user-provided Security Group ID: %d", sgID)
+	} else if len(launch.SecurityGroupIDs) > 0 {
+		sgID = launch.SecurityGroupIDs[0]
+		log.Printf("[INFO] Using launch template Security Group ID: %d", sgID)
+		if err := d.Set("my_account_sg_id", sgID); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set my_account_sg_id: %v", err))
+		}
 	} else {
 		sgID, err = apiClient.GetDefaultSecurityGroupID(projectID, location)
 		if err != nil {
@@ -304,10 +449,13 @@ func resourceCreateScalerGroup(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(fmt.Errorf("failed to set security_group_ids: %v", err))
 	}
 
-	req, err := expandCreateScalerGroupRequest(d, m.(*client.Client), projectID, location, sgID)
+	req, err := expandCreateScalerGroupRequest(d, m.(*client.Client), launch, projectID, location, sgID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := checkScalerGroupName(apiClient, req.Name, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
 
 	requestJSON, _ := json.MarshalIndent(req, "", "  ")
 	log.Printf("[DEBUG] CreateScalerGroup Request JSON:\n%s", requestJSON)
@@ -320,6 +468,19 @@ func resourceCreateScalerGroup(ctx context.Context, d *schema.ResourceData, m in
 	log.Printf("[INFO] ScalerGroup created with ID: %s", resp.ID)
 	d.SetId(resp.ID)
 
+	if len(d.Get("launch_template").([]interface{})) > 0 {
+		d.Set("launch_template_version", 1)
+		if len(launch.SecurityGroupIDs) > 1 {
+			// Only one security group can be given at creation, the rest need a running group
+			if _, err := waitForScalerGroupStatus(ctx, apiClient, resp.ID, projectID, location, "Running"); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := updateScalerGroupSecurityGroups(apiClient, resp.ID, []int{sgID}, launch.SecurityGroupIDs, projectID, location); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	return resourceReadScalerGroup(ctx, d, m)
 }
 
@@ -356,6 +517,25 @@ func resourceReadScalerGroup(ctx context.Context, d *schema.ResourceData, m inte
 	if err := d.Set("desired", group.Desired); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set desired: %v", err))
 	}
+	if err := d.Set("current_nodes", group.Running); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set current_nodes: %v", err))
+	}
+	if err := d.Set("nodes", flattenScalerGroupNodes(group.Nodes)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set nodes: %v", err))
+	}
+	// Cleared hooks and disabled checks read back as empty settings, not as blocks
+	_, hasHooks := d.GetOk("lifecycle_hooks")
+	if group.LifecycleHooks != nil && (hasHooks || len(group.LifecycleHooks.StartScripts) > 0 || group.LifecycleHooks.DrainTimeout > 0) {
+		if err := d.Set("lifecycle_hooks", flattenScalerGroupLifecycleHooks(group.LifecycleHooks)); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set lifecycle_hooks: %v", err))
+		}
+	}
+	_, hasHealthCheck := d.GetOk("health_check")
+	if group.HealthCheck != nil && (hasHealthCheck || group.HealthCheck.Enabled) {
+		if err := d.Set("health_check", flattenScalerGroupHealthCheck(group.HealthCheck)); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set health_check: %v", err))
+		}
+	}
 	if err := d.Set("min_nodes", group.MinNodes); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set min_nodes: %v", err))
 	}
@@ -470,9 +650,9 @@ func resourceDeleteScalerGroup(ctx context.Context, d *schema.ResourceData, m in
 	return nil
 }
 
-func expandCreateScalerGroupRequest(d *schema.ResourceData, client *client.Client, projectID, location string, sgID int) (*models.CreateScalerGroupRequest, error) {
-	planName := d.Get("plan_name").(string)
-	imageName := d.Get("vm_image_name").(string)
+func expandCreateScalerGroupRequest(d *schema.ResourceData, client *client.Client, launch scalerLaunchConfig, projectID, location string, sgID int) (*models.CreateScalerGroupRequest, error) {
+	planName := launch.PlanName
+	imageName := launch.VMImageName
 
 	image, err := client.GetSavedImageByName(imageName, projectID, location)
 	if err != nil {
@@ -484,21 +664,10 @@ func expandCreateScalerGroupRequest(d *schema.ResourceData, client *client.Clien
 		return nil, fmt.Errorf("failed to fetch plan details: %v", err)
 	}
 
+	policies := expandScalerGroupPolicies(d)
 	var elasticPolicies []models.ElasticPolicy
-	if v, ok := d.GetOk("policy"); ok {
-		for _, p := range v.([]interface{}) {
-			pMap := p.(map[string]interface{})
-			elasticPolicies = append(elasticPolicies, models.ElasticPolicy{
-				Type:          pMap["type"].(string),
-				Adjust:        pMap["adjust"].(int),
-				Parameter:     pMap["parameter"].(string),
-				Operator:      pMap["operator"].(string),
-				Value:         pMap["value"].(string),
-				PeriodNumber:  pMap["period_number"].(string),
-				PeriodSeconds: pMap["period_seconds"].(string),
-				Cooldown:      pMap["cooldown"].(string),
-			})
-		}
+	if len(policies.Policies) > 0 {
+		elasticPolicies = policies.Policies
 	}
 
 	var schedPolicies []models.ScheduledPolicy
@@ -555,226 +724,290 @@ func expandCreateScalerGroupRequest(d *schema.ResourceData, client *client.Clien
 		VMImageID:            image.ImageID,
 		VMTemplateID:         image.TemplateID,
 		MyAccountSGID:        sgID,
-		IsEncryptionEnabled:  d.Get("is_encryption_enabled").(bool),
-		EncryptionPassphrase: d.Get("encryption_passphrase").(string),
-		IsPublicIPRequired:   d.Get("is_public_ip_required").(bool),
+		IsEncryptionEnabled:  launch.IsEncryptionEnabled,
+		EncryptionPassphrase: launch.EncryptionPassphrase,
+		IsPublicIPRequired:   launch.IsPublicIPRequired,
 		MinNodes:             strconv.Itoa(d.Get("min_nodes").(int)),
 		MaxNodes:             strconv.Itoa(d.Get("max_nodes").(int)),
 		Desired:              strconv.Itoa(d.Get("desired").(int)),
-		PolicyType:           d.Get("policy_type").(string),
+		PolicyType:           policies.PolicyType,
+		CustomParamName:      policies.CustomParamName,
 		Policy:               elasticPolicies,
 		ScheduledPolicy:      schedPolicies,
 		VPC:                  vpcDetails,
+		LifecycleHooks:       expandScalerGroupLifecycleHooks(d),
+		HealthCheck:          expandScalerGroupHealthCheck(d),
 	}, nil
 }
 
+// resourceUpdateScalerGroup applies every changed attribute in one apply. Changes
+// are sequenced by the state the group has to be in for them: VPCs and the public
+// IP need a stopped group, security groups a running one, and the group is brought
+// to the requested provision_status at the end. Between steps it waits for the
+// group to settle.
 func resourceUpdateScalerGroup(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
 	projectID := d.Get("project_id").(string)
 	location := d.Get("location").(string)
 	id := d.Id()
 
-	if d.HasChange("provision_status") {
-		oldStatus, newStatus := d.GetChange("provision_status")
-		log.Printf("[INFO] Changing provision_status from %s → %s", oldStatus, newStatus)
-
-		intID, err := strconv.Atoi(id)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("invalid scaler group ID: %v", err))
-		}
-
-		if err := apiClient.UpdateScalerGroupStatus(intID, newStatus.(string), projectID, location); err != nil {
-			return diag.FromErr(fmt.Errorf("failed to update provision_status to %s: %v", newStatus, err))
-		}
-
-		return resourceReadScalerGroup(ctx, d, m)
-	}
-
 	minNodes := d.Get("min_nodes").(int)
 	maxNodes := d.Get("max_nodes").(int)
 	desired := d.Get("desired").(int)
-
+	desiredChanged := d.HasChange("desired")
+	if !d.Get("manage_desired").(bool) {
+		// Leave the autoscaler's count alone unless the new bounds exclude it
+		clamped := min(max(desired, minNodes), maxNodes)
+		desiredChanged = clamped != desired
+		desired = clamped
+	}
 	if desired < minNodes || desired > maxNodes {
 		return diag.Errorf("desired node count (%d) must be between min_nodes (%d) and max_nodes (%d)", desired, minNodes, maxNodes)
 	}
 
-	// If only desired changed, call separate API
-	if d.HasChange("desired") &&
-		!(d.HasChange("min_nodes") || d.HasChange("max_nodes") || d.HasChange("policy_type") || d.HasChange("policy") || d.HasChange("scheduled_policy")) {
-		log.Printf("[INFO] Only desired node count changed; using separate API.")
-		intID, err := strconv.Atoi(id)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("invalid scaler group ID: %v", err))
-		}
-		if err := apiClient.UpdateDesiredNodeCount(intID, desired, projectID, location); err != nil {
-			return diag.FromErr(fmt.Errorf("failed to update desired node count: %v", err))
-		}
-		return resourceReadScalerGroup(ctx, d, m)
+	status, err := waitForScalerGroupSettled(ctx, apiClient, id, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetStatus := status
+	if v, ok := d.GetOk("provision_status"); ok {
+		targetStatus = v.(string)
 	}
 
-	if d.HasChange("security_group_ids") {
-		log.Printf("[INFO] Detected change in security_group_ids for Scaler Group %s", id)
-
-		group, err := apiClient.GetScalerGroup(id, projectID, location)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to fetch scaler group status: %w", err))
+	oldLaunch, newLaunch := scalerLaunchConfigChange(d)
+	rollNodes := oldLaunch.rolledBy(newLaunch)
+	if rollNodes {
+		if trimImageName(oldLaunch.VMImageName) != trimImageName(newLaunch.VMImageName) {
+			savedImage, err := apiClient.GetSavedImageByName(newLaunch.VMImageName, projectID, location)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to fetch saved image details for '%s': %v", newLaunch.VMImageName, err))
+			}
+			d.Set("vm_image_id", savedImage.ImageID)
+			d.Set("vm_template_id", savedImage.TemplateID)
 		}
-		if group.ProvisionStatus != "Running" {
-			return diag.Errorf("Scaler group must be in 'Running' state to update security groups. Current: %s", group.ProvisionStatus)
+		planID, slugName, err := apiClient.GetPlanDetailsFromPlanName(d.Get("vm_template_id").(int), newLaunch.PlanName, projectID, location)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to fetch plan details: %v", err))
 		}
+		d.Set("plan_id", planID)
+		d.Set("sku_id", planID)
+		d.Set("slug_name", slugName)
+	}
 
-		oldRaw, newRaw := d.GetChange("security_group_ids")
-		oldList := expandIntList(oldRaw.([]interface{}))
-		newList := expandIntList(newRaw.([]interface{}))
-
-		if len(newList) == 0 {
-			return diag.Errorf("At least one security group must be attached to the scaler group")
+	publicIPChanged := oldLaunch.IsPublicIPRequired != newLaunch.IsPublicIPRequired
+	if d.HasChange("vpc") || publicIPChanged {
+		if status, err = setScalerGroupStatus(ctx, apiClient, id, status, "Stopped", projectID, location); err != nil {
+			return diag.FromErr(err)
 		}
-
-		oldStr := intSliceToStringSlice(oldList)
-		newStr := intSliceToStringSlice(newList)
-
-		toAttach := difference(newStr, oldStr)
-		toDetach := difference(oldStr, newStr)
-
-		for _, sgIDStr := range toAttach {
-			sgID, _ := strconv.Atoi(sgIDStr)
-			log.Printf("[INFO] Attaching Security Group ID %d", sgID)
-			if err := apiClient.AddSecurityGroupToScalergroup(id, sgID, projectID, location); err != nil {
-				return diag.FromErr(fmt.Errorf("failed to attach SG %d: %v", sgID, err))
+		if d.HasChange("vpc") {
+			if err := updateScalerGroupVPCs(d, apiClient, id, projectID, location); err != nil {
+				return diag.FromErr(err)
 			}
 		}
-
-		for _, sgIDStr := range toDetach {
-			sgID, _ := strconv.Atoi(sgIDStr)
-			log.Printf("[INFO] Detaching Security Group ID %d", sgID)
-			if err := apiClient.DetachSecurityGroupFromScalergroup(id, sgID, projectID, location); err != nil {
-				return diag.FromErr(fmt.Errorf("failed to detach SG %d: %v", sgID, err))
+		if publicIPChanged {
+			if err := updateScalerGroupPublicIP(d, apiClient, id, newLaunch.IsPublicIPRequired, projectID, location); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
 
-	if d.HasChange("vpc") {
+	if len(newLaunch.SecurityGroupIDs) > 0 && !equalIntLists(oldLaunch.SecurityGroupIDs, newLaunch.SecurityGroupIDs) {
+		if status, err = setScalerGroupStatus(ctx, apiClient, id, status, "Running", projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := updateScalerGroupSecurityGroups(apiClient, id, oldLaunch.SecurityGroupIDs, newLaunch.SecurityGroupIDs, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := waitForScalerGroupStatus(ctx, apiClient, id, projectID, location, "Running"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-		group, err := apiClient.GetScalerGroup(d.Id(), projectID, location)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to fetch scaler group status for update: %w", err))
+	configChanged := rollNodes || d.HasChanges("min_nodes", "max_nodes", "policy_type", "policy", "step_policy", "target_tracking_policy", "scheduled_policy", "lifecycle_hooks", "health_check")
+	// Shrinking max_nodes below the current desired count only works once desired is lowered
+	oldDesired, _ := d.GetChange("desired")
+	desiredFirst := maxNodes < oldDesired.(int)
+	if desiredChanged && desiredFirst {
+		if err := updateScalerGroupDesired(ctx, apiClient, id, desired, status, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if configChanged {
+		if err := updateScalerGroupConfig(d, apiClient, id, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := waitForScalerGroupStatus(ctx, apiClient, id, projectID, location, status); err != nil {
+			return diag.FromErr(err)
 		}
-		if group.ProvisionStatus != "Stopped" {
-			return diag.Errorf("VPCs can only be attached or detached when the scaler group is in 'stopped' state. Current state: %q", group.ProvisionStatus)
+	}
+	if desiredChanged && !desiredFirst {
+		if err := updateScalerGroupDesired(ctx, apiClient, id, desired, status, projectID, location); err != nil {
+			return diag.FromErr(err)
 		}
+	}
+	if rollNodes {
+		// New nodes are launched with the new settings, the old ones have to be replaced
+		if status, err = setScalerGroupStatus(ctx, apiClient, id, status, "Running", projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := rollScalerGroupNodes(ctx, d, apiClient, id, desired, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-		oldRaw, newRaw := d.GetChange("vpc")
-		oldList := extractVpcNames(oldRaw.([]interface{}))
-		newList := extractVpcNames(newRaw.([]interface{}))
+	if _, err := setScalerGroupStatus(ctx, apiClient, id, status, targetStatus, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
 
-		toAttach := difference(newList, oldList)
-		toDetach := difference(oldList, newList)
+	return resourceReadScalerGroup(ctx, d, m)
+}
 
-		for _, vpcName := range toAttach {
-			vpcDetails, err := apiClient.GetVpcDetailsByName(projectID, location, vpcName)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to get VPC details for name %q: %w", vpcName, err))
-			}
-			err = apiClient.AttachVPCToScalerGroup(d.Id(), []models.VPCDetail{*vpcDetails}, projectID, location)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to attach VPC %q: %w", vpcName, err))
-			}
-		}
+// scalerGroupStableStatuses are the provision statuses a scaler group rests in.
+var scalerGroupStableStatuses = []string{"Running", "Stopped"}
 
-		for _, vpcName := range toDetach {
-			vpcDetails, err := apiClient.GetVpcDetailsByName(projectID, location, vpcName)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to get VPC ID for name %q: %w", vpcName, err))
-			}
-			err = apiClient.DetachVPCFromScalerGroup(d.Id(), strconv.Itoa(vpcDetails.NetworkID), projectID, location)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to detach VPC %q: %w", vpcName, err))
+// waitForScalerGroupStatus waits until the scaler group reaches one of the given
+// provision statuses and returns it. It is bounded by the deadline of ctx.
+func waitForScalerGroupStatus(ctx context.Context, apiClient *client.Client, id string, projectID, location string, statuses ...string) (string, error) {
+	for {
+		group, err := apiClient.GetScalerGroup(id, projectID, location)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch scaler group status: %w", err)
+		}
+		for _, status := range statuses {
+			if group.ProvisionStatus == status {
+				return status, nil
 			}
 		}
+		log.Printf("[INFO] Scaler group %s is %s, waiting for %s", id, group.ProvisionStatus, strings.Join(statuses, " or "))
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("timed out waiting for scaler group %s to be %s, current: %s", id, strings.Join(statuses, " or "), group.ProvisionStatus)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
 
-		vpcNames := extractVpcNames(d.Get("vpc").([]interface{}))
-		vpcStateList := []map[string]interface{}{}
+func waitForScalerGroupSettled(ctx context.Context, apiClient *client.Client, id string, projectID, location string) (string, error) {
+	return waitForScalerGroupStatus(ctx, apiClient, id, projectID, location, scalerGroupStableStatuses...)
+}
 
-		for _, vpcName := range vpcNames {
-			vpcDetails, err := apiClient.GetVpcDetailsByName(projectID, location, vpcName)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to refresh VPC details for %q: %w", vpcName, err))
-			}
+// setScalerGroupStatus starts or stops the scaler group when it is not already in
+// the wanted status and waits for it to get there.
+func setScalerGroupStatus(ctx context.Context, apiClient *client.Client, id string, current, wanted string, projectID, location string) (string, error) {
+	if current == wanted {
+		return current, nil
+	}
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return current, fmt.Errorf("invalid scaler group ID: %v", err)
+	}
+	log.Printf("[INFO] Changing provision_status from %s → %s", current, wanted)
+	if err := apiClient.UpdateScalerGroupStatus(intID, wanted, projectID, location); err != nil {
+		return current, fmt.Errorf("failed to update provision_status to %s: %v", wanted, err)
+	}
+	return waitForScalerGroupStatus(ctx, apiClient, id, projectID, location, wanted)
+}
 
-			subnetList := []map[string]interface{}{}
-			for _, sn := range vpcDetails.Subnets {
-				subnetList = append(subnetList, map[string]interface{}{
-					"id":          sn.ID,
-					"subnet_name": sn.SubnetName,
-					"cidr":        sn.CIDR,
-					"used_ips":    sn.UsedIPs,
-					"total_ips":   sn.TotalIPs,
-				})
-			}
+func updateScalerGroupDesired(ctx context.Context, apiClient *client.Client, id string, desired int, status string, projectID, location string) error {
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid scaler group ID: %v", err)
+	}
+	log.Printf("[INFO] Updating desired node count of scaler group %s to %d", id, desired)
+	if err := apiClient.UpdateDesiredNodeCount(intID, desired, projectID, location); err != nil {
+		return fmt.Errorf("failed to update desired node count: %v", err)
+	}
+	_, err = waitForScalerGroupStatus(ctx, apiClient, id, projectID, location, status)
+	return err
+}
 
-			vpcStateList = append(vpcStateList, map[string]interface{}{
-				"name":       vpcDetails.Name,
-				"network_id": vpcDetails.NetworkID,
-				"ipv4_cidr":  vpcDetails.IPv4CIDR,
-				"state":      vpcDetails.State,
-				"subnets":    subnetList,
-			})
+func updateScalerGroupSecurityGroups(apiClient *client.Client, id string, oldList, newList []int, projectID, location string) error {
+	log.Printf("[INFO] Detected change in security groups for Scaler Group %s", id)
+
+	if len(newList) == 0 {
+		return fmt.Errorf("At least one security group must be attached to the scaler group")
+	}
+
+	oldStr := intSliceToStringSlice(oldList)
+	newStr := intSliceToStringSlice(newList)
+
+	toAttach := difference(newStr, oldStr)
+	toDetach := difference(oldStr, newStr)
+
+	for _, sgIDStr := range toAttach {
+		sgID, _ := strconv.Atoi(sgIDStr)
+		log.Printf("[INFO] Attaching Security Group ID %d", sgID)
+		if err := apiClient.AddSecurityGroupToScalergroup(id, sgID, projectID, location); err != nil {
+			return fmt.Errorf("failed to attach SG %d: %v", sgID, err)
 		}
+	}
 
-		if err := d.Set("vpc", vpcStateList); err != nil {
-			return diag.FromErr(fmt.Errorf("failed to set vpc state: %w", err))
+	for _, sgIDStr := range toDetach {
+		sgID, _ := strconv.Atoi(sgIDStr)
+		log.Printf("[INFO] Detaching Security Group ID %d", sgID)
+		if err := apiClient.DetachSecurityGroupFromScalergroup(id, sgID, projectID, location); err != nil {
+			return fmt.Errorf("failed to detach SG %d: %v", sgID, err)
 		}
 	}
+	return nil
+}
 
-	if d.HasChange("is_public_ip_required") {
-		oldVal, newVal := d.GetChange("is_public_ip_required")
-		log.Printf("[INFO] is_public_ip_required changed from %v to %v", oldVal, newVal)
+func updateScalerGroupVPCs(d *schema.ResourceData, apiClient *client.Client, id string, projectID, location string) error {
+	oldRaw, newRaw := d.GetChange("vpc")
+	oldList := extractVpcNames(oldRaw.([]interface{}))
+	newList := extractVpcNames(newRaw.([]interface{}))
 
-		currentStatus := d.Get("provision_status").(string)
-		if currentStatus != "Stopped" {
-			return diag.Errorf("ScalerGroup must be in 'Stopped' state to attach/detach public IP")
-		}
+	toAttach := difference(newList, oldList)
+	toDetach := difference(oldList, newList)
 
-		vpcsRaw, ok := d.GetOk("vpc")
-		if !ok || len(vpcsRaw.([]interface{})) == 0 {
-			return diag.Errorf("At least one VPC must be attached to attach/detach public IP")
+	for _, vpcName := range toAttach {
+		vpcDetails, err := apiClient.GetVpcDetailsByName(projectID, location, vpcName)
+		if err != nil {
+			return fmt.Errorf("failed to get VPC details for name %q: %w", vpcName, err)
+		}
+		err = apiClient.AttachVPCToScalerGroup(id, []models.VPCDetail{*vpcDetails}, projectID, location)
+		if err != nil {
+			return fmt.Errorf("failed to attach VPC %q: %w", vpcName, err)
 		}
+	}
 
-		if newVal.(bool) {
-			log.Printf("[INFO] Triggering Public IP ATTACH")
-			_, err := apiClient.AttachPublicIP(d.Id(), projectID, location)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to attach public IP: %v", err))
-			}
-		} else {
-			log.Printf("[INFO] Triggering Public IP DETACH")
-			_, err := apiClient.DetachPublicIP(d.Id(), projectID, location)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to detach public IP: %v", err))
-			}
+	for _, vpcName := range toDetach {
+		vpcDetails, err := apiClient.GetVpcDetailsByName(projectID, location, vpcName)
+		if err != nil {
+			return fmt.Errorf("failed to get VPC ID for name %q: %w", vpcName, err)
+		}
+		err = apiClient.DetachVPCFromScalerGroup(id, strconv.Itoa(vpcDetails.NetworkID), projectID, location)
+		if err != nil {
+			return fmt.Errorf("failed to detach VPC %q: %w", vpcName, err)
 		}
 	}
+	return nil
+}
 
-	if !(d.HasChange("min_nodes") || d.HasChange("max_nodes") || d.HasChange("policy_type") || d.HasChange("policy") || d.HasChange("scheduled_policy")) {
-		log.Println("[INFO] No relevant changes detected, skipping update.")
-		return nil
+func updateScalerGroupPublicIP(d *schema.ResourceData, apiClient *client.Client, id string, required bool, projectID, location string) error {
+	log.Printf("[INFO] is_public_ip_required changed to %v", required)
+
+	vpcsRaw, ok := d.GetOk("vpc")
+	if !ok || len(vpcsRaw.([]interface{})) == 0 {
+		return fmt.Errorf("At least one VPC must be attached to attach/detach public IP")
 	}
 
-	policies := []models.ElasticPolicy{}
-	for _, p := range d.Get("policy").([]interface{}) {
-		pMap := p.(map[string]interface{})
-		policies = append(policies, models.ElasticPolicy{
-			Type:          pMap["type"].(string),
-			Adjust:        pMap["adjust"].(int),
-			Parameter:     pMap["parameter"].(string),
-			Operator:      pMap["operator"].(string),
-			Value:         pMap["value"].(string),
-			PeriodNumber:  pMap["period_number"].(string),
-			PeriodSeconds: pMap["period_seconds"].(string),
-			Cooldown:      pMap["cooldown"].(string),
-		})
+	if required {
+		log.Printf("[INFO] Triggering Public IP ATTACH")
+		if _, err := apiClient.AttachPublicIP(id, projectID, location); err != nil {
+			return fmt.Errorf("failed to attach public IP: %v", err)
+		}
+	} else {
+		log.Printf("[INFO] Triggering Public IP DETACH")
+		if _, err := apiClient.DetachPublicIP(id, projectID, location); err != nil {
+			return fmt.Errorf("failed to detach public IP: %v", err)
+		}
 	}
+	return nil
+}
+
+func updateScalerGroupConfig(d *schema.ResourceData, apiClient *client.Client, id string, projectID, location string) error {
+	policies := expandScalerGroupPolicies(d)
 
 	schedPolicies := []models.ScheduledPolicy{}
 	for _, s := range d.Get("scheduled_policy").([]interface{}) {
@@ -786,26 +1019,45 @@ func resourceUpdateScalerGroup(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
-	var policyType string
-	if len(policies) > 0 {
-		policyType = d.Get("policy_type").(string)
+	hooks := expandScalerGroupLifecycleHooks(d)
+	if hooks == nil && d.HasChange("lifecycle_hooks") {
+		// Removing the block clears the hooks instead of leaving them untouched
+		hooks = &models.ScalerGroupLifecycleHooks{StartScripts: []interface{}{}}
+	}
+	healthCheck := expandScalerGroupHealthCheck(d)
+	if healthCheck == nil && d.HasChange("health_check") {
+		healthCheck = &models.ScalerGroupHealthCheck{Enabled: false}
+	}
+
+	var policyType, customParamName string
+	if len(policies.Policies) > 0 {
+		policyType = policies.PolicyType
+		customParamName = policies.CustomParamName
 	}
+	launch := expandScalerLaunchConfig(d)
 	req := &models.UpdateScalerGroupRequest{
-		Name:            d.Get("name").(string),
-		PlanID:          d.Get("plan_id").(string),
-		MinNodes:        minNodes,
-		MaxNodes:        maxNodes,
-		PolicyType:      policyType, // empty string if no elastic policies
-		Policy:          policies,
-		ScheduledPolicy: schedPolicies,
+		Name:                 d.Get("name").(string),
+		PlanID:               d.Get("plan_id").(string),
+		MinNodes:             d.Get("min_nodes").(int),
+		MaxNodes:             d.Get("max_nodes").(int),
+		PolicyType:           policyType, // empty string if no elastic policies
+		CustomParamName:      customParamName,
+		VMImageID:            d.Get("vm_image_id").(string),
+		VMImageName:          launch.VMImageName,
+		VMTemplateID:         d.Get("vm_template_id").(int),
+		IsEncryptionEnabled:  launch.IsEncryptionEnabled,
+		EncryptionPassphrase: launch.EncryptionPassphrase,
+		Policy:               policies.Policies,
+		ScheduledPolicy:      schedPolicies,
+		LifecycleHooks:       hooks,
+		HealthCheck:          healthCheck,
 	}
 
 	log.Printf("[INFO] Updating ScalerGroup %s with new configuration...", id)
 	if err := apiClient.UpdateScalerGroup(id, req, projectID, location); err != nil {
-		return diag.FromErr(fmt.Errorf("failed to update scaler group: %v", err))
+		return fmt.Errorf("failed to update scaler group: %v", err)
 	}
-
-	return resourceReadScalerGroup(ctx, d, m)
+	return nil
 }
 
 func extractVpcNames(vpcs []interface{}) []string {
@@ -849,3 +1101,17 @@ func intSliceToStringSlice(in []int) []string {
 	}
 	return result
 }
+
+func flattenScalerGroupNodes(nodes []models.ScaleGroupNode) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, map[string]interface{}{
+			"id":          n.ID,
+			"name":        n.Name,
+			"private_ips": n.IP,
+			"public_ip":   n.PublicIP,
+			"status":      n.Status,
+		})
+	}
+	return result
+}
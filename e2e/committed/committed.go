@@ -0,0 +1,180 @@
+// Package committed holds the committed (reserved) pricing arguments shared
+// by nodes and DBaaS clusters. A resource on a committed SKU is billed for a
+// fixed term instead of by the hour.
+package committed
+
+import (
+	"fmt"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// What happens at the end of a committed term.
+const (
+	AutoRenew     = "auto_renew"
+	HourlyBilling = "hourly_billing"
+)
+
+// AddSchema adds the committed pricing arguments to a resource schema.
+func AddSchema(s map[string]*schema.Schema) {
+	s["committed_sku"] = &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"committed_days"},
+		ValidateFunc:  validation.IntAtLeast(1),
+		Description:   "ID of the committed SKU of the plan. The resource is billed hourly when neither committed_sku nor committed_days is set",
+	}
+	s["committed_days"] = &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"committed_sku"},
+		ValidateFunc:  validation.IntAtLeast(1),
+		Description:   "Length of the committed term in days, picks the committed SKU of the plan with this term",
+	}
+	s["committed_term_end_action"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      AutoRenew,
+		ValidateFunc: validation.StringInSlice([]string{AutoRenew, HourlyBilling}, false),
+		Description:  "What happens when the committed term ends, auto_renew or hourly_billing",
+	}
+	s["committed_end_date"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "End date of the current committed term",
+	}
+}
+
+// FindSKU returns the committed SKU of a plan by ID or, when id is zero, by
+// term length.
+func FindSKU(skus []models.PlanCommittedSKU, id, days int) (*models.PlanCommittedSKU, error) {
+	for _, sku := range skus {
+		if (id != 0 && sku.SKUID == id) || (id == 0 && sku.SKUDurationDays == days) {
+			return &sku, nil
+		}
+	}
+	var offered []string
+	for _, sku := range skus {
+		offered = append(offered, fmt.Sprintf("%d (%s, %d days)", sku.SKUID, sku.SKUName, sku.SKUDurationDays))
+	}
+	if len(offered) == 0 {
+		return nil, fmt.Errorf("the plan has no committed SKUs")
+	}
+	if id != 0 {
+		return nil, fmt.Errorf("committed_sku %d isn't offered for the plan, pick one of %v", id, offered)
+	}
+	return nil, fmt.Errorf("no committed SKU of %d days is offered for the plan, pick one of %v", days, offered)
+}
+
+// Wanted returns the committed SKU ID and term length set in the
+// configuration, both zero for hourly billing.
+func Wanted(d interface{ GetRawConfig() cty.Value }) (int, int) {
+	return configInt(d.GetRawConfig(), "committed_sku"), configInt(d.GetRawConfig(), "committed_days")
+}
+
+// ValidateDiff fails the plan when the wanted committed SKU isn't offered
+// for the plan, or when an active commitment would change. lookup returns
+// the committed SKUs of the wanted plan and is only called when needed.
+func ValidateDiff(diff *schema.ResourceDiff, planKeys []string, lookup func() ([]models.PlanCommittedSKU, error)) error {
+	if !diff.NewValueKnown("committed_sku") || !diff.NewValueKnown("committed_days") {
+		return nil
+	}
+	id, days := Wanted(diff)
+	if id == 0 && days == 0 {
+		return nil
+	}
+
+	changed := diff.Id() == "" || diff.HasChange("committed_sku") || diff.HasChange("committed_days")
+	for _, key := range planKeys {
+		changed = changed || diff.HasChange(key)
+	}
+	if !changed {
+		return nil
+	}
+
+	if diff.Id() != "" {
+		oldID, _ := diff.GetChange("committed_sku")
+		oldDays, _ := diff.GetChange("committed_days")
+		if oldID.(int) != 0 && ((id != 0 && id != oldID.(int)) || (id == 0 && days != oldDays.(int))) {
+			endDate, _ := diff.GetChange("committed_end_date")
+			return fmt.Errorf("the committed SKU can't change before the committed term ends on %s, set committed_term_end_action to %q to return to hourly billing then", endDate, HourlyBilling)
+		}
+	}
+
+	skus, err := lookup()
+	if err != nil {
+		return err
+	}
+	_, err = FindSKU(skus, id, days)
+	return err
+}
+
+// Resolve returns the committed SKU wanted by the configuration, nil for
+// hourly billing.
+func Resolve(d *schema.ResourceData, lookup func() ([]models.PlanCommittedSKU, error)) (*models.PlanCommittedSKU, error) {
+	id, days := Wanted(d)
+	if id == 0 && days == 0 {
+		return nil, nil
+	}
+	skus, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+	return FindSKU(skus, id, days)
+}
+
+// Update converts an hourly resource to the wanted committed SKU and applies
+// a changed term end action.
+func Update(d *schema.ResourceData, lookup func() ([]models.PlanCommittedSKU, error), apply func(*models.CommittedSettingsRequest) error) error {
+	settings := models.CommittedSettingsRequest{
+		CNStatus: d.Get("committed_term_end_action").(string),
+	}
+	if oldID, _ := d.GetChange("committed_sku"); oldID.(int) == 0 {
+		sku, err := Resolve(d, lookup)
+		if err != nil {
+			return err
+		}
+		if sku != nil {
+			settings.CNID = sku.SKUID
+		}
+	}
+	if settings.CNID == 0 && !d.HasChange("committed_term_end_action") {
+		return nil
+	}
+	if settings.CNID == 0 && d.Get("committed_sku").(int) == 0 {
+		// The term end action only applies to committed resources.
+		return nil
+	}
+	return apply(&settings)
+}
+
+// Set stores the committed term the API reports, an empty one for hourly
+// billing.
+func Set(d *schema.ResourceData, details []models.CommittedSKU) {
+	if len(details) == 0 {
+		d.Set("committed_sku", 0)
+		d.Set("committed_days", 0)
+		d.Set("committed_end_date", "")
+		return
+	}
+	d.Set("committed_sku", details[0].ID)
+	d.Set("committed_days", details[0].Days)
+	d.Set("committed_end_date", details[0].UptoDate)
+}
+
+func configInt(config cty.Value, key string) int {
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(key) {
+		return 0
+	}
+	v := config.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() {
+		return 0
+	}
+	n, _ := v.AsBigFloat().Int64()
+	return int(n)
+}
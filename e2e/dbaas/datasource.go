@@ -0,0 +1,150 @@
+package dbaas
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSource builds the data source of a DBaaS engine, reading an existing
+// cluster of that engine by ID.
+func DataSource(engine Engine) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return dataSourceReadCluster(ctx, engine, d, m)
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the " + engine.Name + " cluster",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Deployment location",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the cluster",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: engine.Name + " version",
+			},
+			"plan": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Plan name",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the cluster",
+			},
+			"power_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the master node",
+			},
+			"database_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Database ID",
+			},
+			"database_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the database",
+			},
+			"database_user": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Database user",
+			},
+			"public_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public IP address",
+			},
+			"private_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Private IP address",
+			},
+			"is_public_ip_attached": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a public IP is attached",
+			},
+			"port": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Port the database listens on",
+			},
+			"disk_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Disk size in GB",
+			},
+			"parameter_group_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Attached Parameter Group ID",
+			},
+		},
+	}
+}
+
+func dataSourceReadCluster(ctx context.Context, engine Engine, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	clusterID := d.Get("id").(string)
+	cluster, err := apiClient.GetDBaaSCluster(clusterID, d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !engine.isSoftware(cluster.Software.Name) {
+		return diag.Errorf("DBaaS cluster %s runs %s, not %s", clusterID, cluster.Software.Name, engine.Name)
+	}
+	master := cluster.MasterNode
+
+	d.SetId(strconv.Itoa(cluster.ID))
+	d.Set("name", cluster.Name)
+	d.Set("version", cluster.Software.Version)
+	d.Set("plan", master.Plan.Name)
+	d.Set("status", cluster.Status)
+	d.Set("power_status", master.Status)
+	d.Set("database_id", master.Database.ID)
+	d.Set("database_name", master.Database.Database)
+	d.Set("database_user", master.Database.Username)
+	d.Set("public_ip", master.PublicIPAddress)
+	d.Set("private_ip", master.PrivateIPAddress)
+	d.Set("is_public_ip_attached", master.PublicIPAddress != "")
+	d.Set("port", master.Port)
+	d.Set("disk_size", diskSize(cluster))
+	d.Set("parameter_group_id", master.Database.PGDetail.ID)
+
+	return diags
+}
+
+func (e Engine) isSoftware(name string) bool {
+	for _, n := range e.softwareNames() {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourcePlans() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceReadPlans,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Location of the plans",
+			},
+			"engine": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return plans of this engine, e.g. PostgreSQL",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return plans of this engine version",
+			},
+			"min_cpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Only return plans with at least this many vCPUs",
+			},
+			"min_ram": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Only return plans with at least this much RAM in GB",
+			},
+			"available_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return plans that are in stock",
+			},
+
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the matching plans",
+			},
+			"plans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching plans",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":            {Type: schema.TypeString, Computed: true},
+						"template_id":     {Type: schema.TypeInt, Computed: true},
+						"software_id":     {Type: schema.TypeInt, Computed: true},
+						"engine":          {Type: schema.TypeString, Computed: true},
+						"version":         {Type: schema.TypeString, Computed: true},
+						"cpu":             {Type: schema.TypeString, Computed: true},
+						"ram":             {Type: schema.TypeString, Computed: true},
+						"disk":            {Type: schema.TypeString, Computed: true},
+						"price":           {Type: schema.TypeString, Computed: true},
+						"price_per_hour":  {Type: schema.TypeFloat, Computed: true},
+						"price_per_month": {Type: schema.TypeFloat, Computed: true},
+						"currency":        {Type: schema.TypeString, Computed: true},
+						"available":       {Type: schema.TypeBool, Computed: true},
+						"committed_skus": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id":    {Type: schema.TypeInt, Computed: true},
+									"name":  {Type: schema.TypeString, Computed: true},
+									"price": {Type: schema.TypeFloat, Computed: true},
+									"days":  {Type: schema.TypeInt, Computed: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadPlans(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	engine := d.Get("engine").(string)
+	version := d.Get("version").(string)
+	minCPU := float64(d.Get("min_cpu").(int))
+	minRAM := float64(d.Get("min_ram").(int))
+	availableOnly := d.Get("available_only").(bool)
+
+	catalogue, err := apiClient.GetDBaaSPlans(projectID, location, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := []string{}
+	plans := []map[string]interface{}{}
+	for _, software := range catalogue.DatabaseEngines {
+		if engine != "" && !strings.EqualFold(software.EngineName, engine) {
+			continue
+		}
+		if version != "" && software.EngineVersion != version {
+			continue
+		}
+
+		softwarePlans, err := apiClient.GetDBaaSPlans(projectID, location, strconv.Itoa(software.EngineID))
+		if err != nil {
+			return diag.Errorf("error reading plans of %s %s: %s", software.EngineName, software.EngineVersion, err)
+		}
+		for _, plan := range softwarePlans.TemplatePlans {
+			if planQuantity(plan.PlanCPUCores) < minCPU || planQuantity(plan.PlanRAMGB) < minRAM {
+				continue
+			}
+			if availableOnly && !plan.IsInventoryAvailable {
+				continue
+			}
+			names = append(names, plan.PlanName)
+			plans = append(plans, flattenPlan(software, plan))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, location))
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("plans", plans); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func flattenPlan(software models.EngineDefinition, plan models.PlanTemplate) map[string]interface{} {
+	skus := make([]map[string]interface{}, 0, len(plan.CommittedSKUs))
+	for _, sku := range plan.CommittedSKUs {
+		skus = append(skus, map[string]interface{}{
+			"id":    sku.SKUID,
+			"name":  sku.SKUName,
+			"price": sku.SKUPrice,
+			"days":  sku.SKUDurationDays,
+		})
+	}
+	return map[string]interface{}{
+		"name":            plan.PlanName,
+		"template_id":     plan.PlanTemplateID,
+		"software_id":     software.EngineID,
+		"engine":          software.EngineName,
+		"version":         software.EngineVersion,
+		"cpu":             plan.PlanCPUCores,
+		"ram":             plan.PlanRAMGB,
+		"disk":            plan.PlanDiskGB,
+		"price":           plan.PlanDisplayPrice,
+		"price_per_hour":  plan.PlanHourlyPrice,
+		"price_per_month": plan.PlanMonthlyPrice,
+		"currency":        plan.PlanCurrency,
+		"available":       plan.IsInventoryAvailable,
+		"committed_skus":  skus,
+	}
+}
+
+// planQuantity reads the number of a catalogue quantity like "16" or
+// "100 GB", zero when there is none.
+func planQuantity(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	n, _ := strconv.ParseFloat(fields[0], 64)
+	return n
+}
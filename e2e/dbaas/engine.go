@@ -0,0 +1,201 @@
+package dbaas
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Engine describes a database engine served through the rds/cluster
+// endpoints. Every engine shares the cluster schema and update flow, the
+// engine only decides which software is installed.
+type Engine struct {
+	// Name is the engine name in the rds/plans catalogue, e.g. "PostgreSQL".
+	Name string
+
+	// SoftwareNames are tried in order instead of Name for engines listed
+	// under more than one name in the catalogue.
+	SoftwareNames []string
+
+	// OptionalDatabaseFields are fields of the database block the engine
+	// doesn't need, e.g. the database name of a key-value store.
+	OptionalDatabaseFields []string
+
+	// PointInTimeRecovery tells whether backups of the engine can enable
+	// point in time recovery.
+	PointInTimeRecovery bool
+
+	// Aliases keep arguments of older engine specific schemas working.
+	Aliases []Alias
+
+	// Schema holds deprecated arguments that have no shared counterpart.
+	Schema map[string]*schema.Schema
+
+	schema map[string]*schema.Schema
+}
+
+// Alias is a deprecated argument standing in for a shared argument.
+type Alias struct {
+	Name      string
+	Canonical string
+
+	// Schema of the alias, a deprecated copy of the shared argument
+	// conflicting with it when nil.
+	Schema *schema.Schema
+
+	// ToCanonical and FromCanonical convert between the alias and the shared
+	// argument. FromCanonical gets the current alias value as well, so an
+	// alias can keep a value meaning the same thing. Both default to identity.
+	ToCanonical   func(v interface{}) interface{}
+	FromCanonical func(v, current interface{}) interface{}
+}
+
+func (e Engine) softwareNames() []string {
+	if len(e.SoftwareNames) > 0 {
+		return e.SoftwareNames
+	}
+	return []string{e.Name}
+}
+
+// applyDatabaseFields makes the fields of the database block the engine
+// doesn't need optional.
+func (e Engine) applyDatabaseFields(s map[string]*schema.Schema) {
+	fields := s["database"].Elem.(*schema.Resource).Schema
+	for _, name := range e.OptionalDatabaseFields {
+		fields[name].Required = false
+		fields[name].Optional = true
+	}
+}
+
+func (e Engine) aliasesOf(key string) []Alias {
+	var aliases []Alias
+	for _, a := range e.Aliases {
+		if a.Canonical == key {
+			aliases = append(aliases, a)
+		}
+	}
+	return aliases
+}
+
+func (a Alias) toCanonical(v interface{}) interface{} {
+	if a.ToCanonical == nil {
+		return v
+	}
+	return a.ToCanonical(v)
+}
+
+func (a Alias) fromCanonical(v, current interface{}) interface{} {
+	if a.FromCanonical == nil {
+		return v
+	}
+	return a.FromCanonical(v, current)
+}
+
+// applyAliases adds the aliases to the shared schema. A required argument
+// becomes optional with exactly one of it and its aliases required.
+func (e Engine) applyAliases(s map[string]*schema.Schema) {
+	for _, a := range e.Aliases {
+		canonical := s[a.Canonical]
+		alias := a.Schema
+		custom := alias != nil
+		if !custom {
+			copied := *canonical
+			copied.Required = false
+			copied.Optional = true
+			copied.Computed = false
+			copied.Default = nil
+			copied.Description = "Deprecated, use " + a.Canonical + " instead."
+			alias = &copied
+		}
+		alias.Deprecated = "Use " + a.Canonical + " instead"
+		alias.ForceNew = canonical.ForceNew
+
+		if canonical.Required || canonical.ExactlyOneOf != nil {
+			canonical.Required = false
+			canonical.Optional = true
+			group := []string{a.Canonical}
+			for _, other := range e.aliasesOf(a.Canonical) {
+				group = append(group, other.Name)
+			}
+			canonical.ExactlyOneOf = group
+			alias.ExactlyOneOf = group
+		} else if !custom {
+			alias.ConflictsWith = append(alias.ConflictsWith, a.Canonical)
+		}
+		s[a.Name] = alias
+	}
+}
+
+// configReader is implemented by both schema.ResourceData and
+// schema.ResourceDiff.
+type configReader interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+}
+
+// value returns the wanted value of a shared argument, taking it from an
+// alias when the configuration uses one.
+func (e Engine) value(d configReader, key string) interface{} {
+	config := d.GetRawConfig()
+	for _, a := range e.aliasesOf(key) {
+		if inUse(config, a.Name) {
+			return a.toCanonical(d.Get(a.Name))
+		}
+	}
+	return d.Get(key)
+}
+
+// oldValue returns the value of a shared argument in the prior state.
+func (e Engine) oldValue(d *schema.ResourceData, key string) interface{} {
+	state := d.GetRawState()
+	for _, a := range e.aliasesOf(key) {
+		if inUse(state, a.Name) {
+			o, _ := d.GetChange(a.Name)
+			return a.toCanonical(o)
+		}
+	}
+	o, _ := d.GetChange(key)
+	return o
+}
+
+func (e Engine) hasChange(d *schema.ResourceData, key string) bool {
+	o, n := e.oldValue(d, key), e.value(d, key)
+	if oSet, ok := o.(*schema.Set); ok {
+		return !oSet.Equal(n)
+	}
+	return o != n
+}
+
+// set stores a value read from the API in whichever of the shared argument
+// and its aliases the configuration uses, so neither shows a diff. Computed
+// shared arguments are always kept up to date.
+func (e Engine) set(d *schema.ResourceData, key string, v interface{}) error {
+	values := d.GetRawConfig()
+	if values.IsNull() {
+		values = d.GetRawState()
+	}
+	for _, a := range e.aliasesOf(key) {
+		if inUse(values, a.Name) {
+			if err := d.Set(a.Name, a.fromCanonical(v, d.Get(a.Name))); err != nil {
+				return err
+			}
+			if !e.schema[key].Computed {
+				return nil
+			}
+		}
+	}
+	return d.Set(key, v)
+}
+
+func inUse(values cty.Value, key string) bool {
+	if values.IsNull() || !values.IsKnown() || !values.Type().HasAttribute(key) {
+		return false
+	}
+	v := values.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() {
+		return false
+	}
+	if v.CanIterateElements() {
+		return v.LengthInt() > 0
+	}
+	return true
+}
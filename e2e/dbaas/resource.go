@@ -0,0 +1,810 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/committed"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/node"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource builds the resource of a DBaaS engine on the shared cluster schema.
+func Resource(engine Engine) *schema.Resource {
+	s := clusterSchema()
+	committed.AddSchema(s)
+	for k, v := range engine.Schema {
+		s[k] = v
+	}
+	engine.applyDatabaseFields(s)
+	engine.applyAliases(s)
+	engine.schema = s
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceCreateCluster(ctx, engine, d, m)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceReadCluster(ctx, engine, d, m)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceUpdateCluster(ctx, engine, d, m)
+		},
+		DeleteContext: resourceDeleteCluster,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+			if err := validateDiskSize(engine, diff); err != nil {
+				return err
+			}
+			if err := validateTrustedSources(diff); err != nil {
+				return err
+			}
+			if err := validateBackup(engine, diff); err != nil {
+				return err
+			}
+			return validatePlan(engine, diff, m.(*client.Client))
+		},
+		Importer: &schema.ResourceImporter{
+			State: node.CustomImportStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func clusterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the project the cluster belongs to",
+		},
+		"location": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Location the cluster is deployed in",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the cluster",
+		},
+		"version": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: SuppressEquivalentVersion,
+			Description:      "Version of the database engine",
+		},
+		"plan": {
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: SuppressEquivalentName,
+			Description:      "Name of the plan. The cluster is stopped while the plan changes",
+		},
+		"group": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "Default",
+			Description: "Group the cluster belongs to",
+		},
+		"database": {
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "Database created with the cluster",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"user": {
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    true,
+						Description: "The database username.",
+					},
+					"password": {
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    true,
+						Sensitive:   true,
+						Description: "The database password.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    true,
+						Description: "The database name.",
+					},
+					"dbaas_number": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						ForceNew:    true,
+						Default:     1,
+						Description: "Number of database instances.",
+					},
+				},
+			},
+		},
+		"vpcs": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Description: "IDs of the VPCs attached to the cluster",
+		},
+		"public_ip_required": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether a public IP is attached to the cluster",
+		},
+		"trusted_sources":    trustedSourcesSchema(),
+		"maintenance_window": maintenanceWindowSchema(),
+		"backup":             backupSchema(),
+		"parameter_group_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "ID of the parameter group attached to the cluster",
+		},
+		"disk_size": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Total disk size in GB. The disk can only grow and the cluster is stopped while it does",
+		},
+		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateStatus,
+			StateFunc:    func(v interface{}) string { return normalizeStatus(v.(string)) },
+			Description:  "Wanted status of the cluster, RUNNING or STOPPED",
+		},
+		"restart_trigger": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Any change of this value restarts the cluster",
+		},
+		"is_encryption_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Whether the disk of the cluster is encrypted",
+		},
+		"encryption_passphrase": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Sensitive:    true,
+			RequiredWith: []string{"is_encryption_enabled"},
+			Description:  "Passphrase of the disk encryption",
+		},
+		"restore_from_snapshot": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"clone_of"},
+			Description:   "ID of a snapshot to create the cluster from",
+		},
+		"clone_of": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"restore_from_snapshot"},
+			Description:   "ID of a cluster to create the cluster as a copy of",
+		},
+		"clone_point_in_time": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: []string{"clone_of"},
+			ValidateFunc: validation.IsRFC3339Time,
+			Description:  "Copy the data of clone_of as it was at this RFC 3339 time instead of its current data",
+		},
+
+		"status_title": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status_actions": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"num_instances": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"project_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"snapshot_exist": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"connectivity_detail": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"vector_database_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"public_ip_address": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"private_ip_address": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"port": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"software_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"template_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func resourceCreateCluster(ctx context.Context, engine Engine, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	softwareID, plan, err := lookupPlan(apiClient, engine, d, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	templateID := plan.PlanTemplateID
+
+	vpcs, err := apiClient.ExpandVpcList(engine.value(d, "vpcs").(*schema.Set).List(), projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dbConfig := d.Get("database").([]interface{})[0].(map[string]interface{})
+	req := models.DBCreateRequest{
+		Name:             engine.value(d, "name").(string),
+		SoftwareID:       softwareID,
+		TemplateID:       templateID,
+		PublicIPRequired: engine.value(d, "public_ip_required").(bool),
+		Group:            d.Get("group").(string),
+		VPCs:             vpcs,
+		Database: models.DBConfig{
+			User:        dbConfig["user"].(string),
+			Password:    dbConfig["password"].(string),
+			Name:        dbConfig["name"].(string),
+			DBaaSNumber: dbConfig["dbaas_number"].(int),
+		},
+		IsEncryptionEnabled:  d.Get("is_encryption_enabled").(bool),
+		EncryptionPassphrase: d.Get("encryption_passphrase").(string),
+	}
+	if pgID := engine.value(d, "parameter_group_id").(int); pgID != 0 {
+		req.PGID = &pgID
+	}
+	if v, ok := d.GetOk("restore_from_snapshot"); ok {
+		if req.SnapshotID, err = strconv.Atoi(v.(string)); err != nil {
+			return diag.Errorf("restore_from_snapshot must be a snapshot ID, got %s", v)
+		}
+	}
+	if v, ok := d.GetOk("clone_of"); ok {
+		if req.SourceClusterID, err = strconv.Atoi(v.(string)); err != nil {
+			return diag.Errorf("clone_of must be a cluster ID, got %s", v)
+		}
+		req.PointInTime = d.Get("clone_point_in_time").(string)
+	}
+	sku, err := committed.Resolve(d, func() ([]models.PlanCommittedSKU, error) {
+		return plan.CommittedSKUs, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if sku != nil {
+		req.CNID = sku.SKUID
+		req.CNStatus = d.Get("committed_term_end_action").(string)
+	}
+
+	cluster, err := apiClient.CreateDBaaSCluster(&req, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := strconv.Itoa(cluster.ID)
+	d.SetId(id)
+	d.Set("software_id", softwareID)
+	d.Set("template_id", templateID)
+	log.Printf("[INFO] %s cluster %s created, waiting for it to be RUNNING", engine.Name, id)
+
+	cluster, err = waitForClusterStatus(ctx, apiClient, id, projectID, location, "RUNNING")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Trusted sources, maintenance and backup settings, a larger disk and a
+	// stopped cluster can't be asked for at creation, they are applied once
+	// the cluster runs.
+	if sources := d.Get("trusted_sources").(*schema.Set); sources.Len() > 0 {
+		if err := updateTrustedSources(ctx, apiClient, cluster, sources, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := updateMaintenanceWindow(apiClient, d, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateBackup(apiClient, d, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	if size := engine.value(d, "disk_size").(int); size > diskSize(cluster) {
+		if cluster, err = resizeCluster(ctx, apiClient, engine, d, cluster, 0, size); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if _, err := setClusterStatus(ctx, apiClient, cluster, wantedStatus(engine, d), projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceReadCluster(ctx, engine, d, m)
+}
+
+func resourceReadCluster(ctx context.Context, engine Engine, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	cluster, err := apiClient.GetDBaaSCluster(d.Id(), d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	master := cluster.MasterNode
+
+	// Keep the configured spelling, the API may report it in another case
+	version := cluster.Software.Version
+	if current := engine.value(d, "version").(string); SameVersion(current, version) {
+		version = current
+	}
+	plan := master.Plan.Name
+	if current := engine.value(d, "plan").(string); SameName(current, plan) {
+		plan = current
+	}
+	for key, v := range map[string]interface{}{
+		"name":               cluster.Name,
+		"version":            version,
+		"plan":               plan,
+		"status":             normalizeStatus(cluster.Status),
+		"public_ip_required": master.PublicIPAddress != "",
+		"parameter_group_id": master.Database.PGDetail.ID,
+		"disk_size":          diskSize(cluster),
+	} {
+		if err := engine.set(d, key, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.Set("is_encryption_enabled", cluster.IsEncryptionEnabled)
+	d.Set("status_title", cluster.StatusTitle)
+	d.Set("status_actions", cluster.StatusActions)
+	d.Set("num_instances", cluster.NumInstances)
+	d.Set("project_name", cluster.ProjectName)
+	d.Set("snapshot_exist", cluster.SnapshotExist)
+	d.Set("connectivity_detail", cluster.ConnectivityDetail)
+	d.Set("vector_database_status", cluster.VectorDBStatus)
+	d.Set("public_ip_address", master.PublicIPAddress)
+	d.Set("private_ip_address", master.PrivateIPAddress)
+	d.Set("port", master.Port)
+	trustedSources, err := flattenTrustedSources(apiClient, master.AllowedIPs, d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("trusted_sources", trustedSources); err != nil {
+		return diag.FromErr(err)
+	}
+	if cluster.MaintenanceWindow != nil {
+		if err := d.Set("maintenance_window", flattenMaintenanceWindow(cluster.MaintenanceWindow)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if cluster.BackupSettings != nil {
+		if err := d.Set("backup", flattenBackup(cluster.BackupSettings)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if master.Plan.TemplateID != 0 {
+		d.Set("template_id", master.Plan.TemplateID)
+	}
+	committed.Set(d, master.CommittedDetails)
+
+	return diags
+}
+
+// resourceUpdateCluster applies changes in a fixed order for every engine:
+// network first, then the parameter group and settings, then plan and disk
+// with the cluster stopped, and finally the wanted status and restarts.
+func resourceUpdateCluster(ctx context.Context, engine Engine, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	id := d.Id()
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	cluster, err := waitForClusterStatus(ctx, apiClient, id, projectID, location, clusterSettledStatuses...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if engine.hasChange(d, "public_ip_required") {
+		if engine.value(d, "public_ip_required").(bool) {
+			err = apiClient.AttachPublicIPToDBaaSCluster(id, projectID, location)
+		} else {
+			err = apiClient.DetachPublicIPFromDBaaSCluster(id, projectID, location)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("trusted_sources") {
+		if err := updateTrustedSources(ctx, apiClient, cluster, d.Get("trusted_sources").(*schema.Set), projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if engine.hasChange(d, "vpcs") {
+		o := engine.oldValue(d, "vpcs").(*schema.Set)
+		n := engine.value(d, "vpcs").(*schema.Set)
+		if err := updateClusterVPCs(apiClient, id, o, n, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if engine.hasChange(d, "parameter_group_id") {
+		o := engine.oldValue(d, "parameter_group_id").(int)
+		n := engine.value(d, "parameter_group_id").(int)
+		if o != 0 {
+			if err := apiClient.DetachParameterGroupFromDBaaSCluster(id, o, projectID, location); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if n != 0 {
+			if err := apiClient.AttachParameterGroupToDBaaSCluster(id, n, projectID, location); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChange("maintenance_window") {
+		if err := updateMaintenanceWindow(apiClient, d, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("backup") {
+		if err := updateBackup(apiClient, d, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if engine.hasChange(d, "plan") || engine.hasChange(d, "disk_size") {
+		templateID := 0
+		if engine.hasChange(d, "plan") {
+			_, plan, err := lookupPlan(apiClient, engine, d, projectID, location)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			templateID = plan.PlanTemplateID
+		}
+		current := diskSize(cluster)
+		size := 0
+		if engine.hasChange(d, "disk_size") {
+			size = engine.value(d, "disk_size").(int)
+		}
+		if cluster, err = resizeCluster(ctx, apiClient, engine, d, cluster, templateID, max(size, current)); err != nil {
+			return diag.FromErr(err)
+		}
+		if templateID != 0 {
+			d.Set("template_id", templateID)
+		}
+	}
+
+	cluster, err = setClusterStatus(ctx, apiClient, cluster, wantedStatus(engine, d), projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("committed_sku", "committed_days", "committed_term_end_action") {
+		lookup := planCommittedSKUs(apiClient, engine, d, projectID, location)
+		err := committed.Update(d, lookup, func(settings *models.CommittedSettingsRequest) error {
+			return apiClient.UpdateDBaaSClusterCommittedSettings(id, settings, projectID, location)
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("restart_trigger") && cluster.Status == "RUNNING" {
+		if err := apiClient.RestartDBaaSCluster(id, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := waitForClusterStatus(ctx, apiClient, id, projectID, location, "RUNNING"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceReadCluster(ctx, engine, d, m)
+}
+
+func resourceDeleteCluster(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	cluster, err := apiClient.GetDBaaSCluster(id, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cluster.Status == "CREATING" {
+		if _, err := waitForClusterStatus(ctx, apiClient, id, projectID, location, clusterSettledStatuses...); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := apiClient.DeleteDBaaSCluster(id, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
+
+// lookupPlan finds the software and template plan of the wanted version and
+// plan in the rds/plans catalogue.
+func lookupPlan(apiClient *client.Client, engine Engine, d configReader, projectID, location string) (int, *models.PlanTemplate, error) {
+	version := engine.value(d, "version").(string)
+	softwareID, err := lookupSoftware(apiClient, engine, version, projectID, location)
+	if err != nil {
+		return 0, nil, err
+	}
+	name := engine.value(d, "plan").(string)
+	plan, err := FindPlan(apiClient, name, softwareID, projectID, location)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to find plan %s for %s %s in %s: %v", name, engine.Name, version, location, err)
+	}
+	return softwareID, plan, nil
+}
+
+func lookupSoftware(apiClient *client.Client, engine Engine, version, projectID, location string) (int, error) {
+	var err error
+	for _, name := range engine.softwareNames() {
+		var softwareID int
+		if softwareID, err = FindSoftware(apiClient, name, version, projectID, location); err == nil {
+			return softwareID, nil
+		}
+	}
+	return 0, fmt.Errorf("failed to find %s %s: %v", engine.Name, version, err)
+}
+
+// FindSoftware returns the ID of an engine version in the rds/plans
+// catalogue, matching the name with SameName and the version with
+// SameVersion like the diffs of these arguments do.
+func FindSoftware(apiClient *client.Client, name, version, projectID, location string) (int, error) {
+	plans, err := apiClient.GetDBaaSPlans(projectID, location, "")
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range plans.DatabaseEngines {
+		if SameName(item.EngineName, name) && SameVersion(item.EngineVersion, version) {
+			return item.EngineID, nil
+		}
+	}
+	return 0, fmt.Errorf("matching engine not found")
+}
+
+// FindPlan returns the template plan of a software by name, matching it with
+// SameName.
+func FindPlan(apiClient *client.Client, name string, softwareID int, projectID, location string) (*models.PlanTemplate, error) {
+	plans, err := apiClient.GetDBaaSPlans(projectID, location, strconv.Itoa(softwareID))
+	if err != nil {
+		return nil, err
+	}
+	for i := range plans.TemplatePlans {
+		if SameName(plans.TemplatePlans[i].PlanName, name) {
+			return &plans.TemplatePlans[i], nil
+		}
+	}
+	return nil, fmt.Errorf("matching plan not found")
+}
+
+func updateClusterVPCs(apiClient *client.Client, id string, o, n *schema.Set, projectID, location string) error {
+	if detach := o.Difference(n); detach.Len() > 0 {
+		vpcs, err := apiClient.ExpandVpcList(detach.List(), projectID, location)
+		if err != nil {
+			return err
+		}
+		if err := apiClient.DetachVPCFromDBaaSCluster(id, vpcs, projectID, location); err != nil {
+			return err
+		}
+	}
+	if attach := n.Difference(o); attach.Len() > 0 {
+		vpcs, err := apiClient.ExpandVpcList(attach.List(), projectID, location)
+		if err != nil {
+			return err
+		}
+		if err := apiClient.AttachVPCToDBaaSCluster(id, vpcs, projectID, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resizeCluster stops the cluster, moves it to templateID when set and grows
+// its disk to size GB when larger than now. The cluster is left stopped,
+// setClusterStatus brings it back to the wanted status.
+func resizeCluster(ctx context.Context, apiClient *client.Client, engine Engine, d *schema.ResourceData, cluster *models.DB, templateID, size int) (*models.DB, error) {
+	id := d.Id()
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	cluster, err := setClusterStatus(ctx, apiClient, cluster, "STOPPED", projectID, location)
+	if err != nil {
+		return cluster, err
+	}
+	if templateID != 0 {
+		log.Printf("[INFO] Upgrading %s cluster %s to plan %s", engine.Name, id, engine.value(d, "plan"))
+		if err := apiClient.UpgradeDBaaSClusterPlan(id, templateID, projectID, location); err != nil {
+			return cluster, err
+		}
+		if cluster, err = waitForClusterStatus(ctx, apiClient, id, projectID, location, clusterSettledStatuses...); err != nil {
+			return cluster, err
+		}
+	}
+	if grow := size - diskSize(cluster); grow > 0 {
+		log.Printf("[INFO] Growing disk of %s cluster %s by %d GB", engine.Name, id, grow)
+		if err := apiClient.ExpandDBaaSClusterDisk(id, grow, projectID, location); err != nil {
+			return cluster, err
+		}
+		if cluster, err = waitForClusterStatus(ctx, apiClient, id, projectID, location, clusterSettledStatuses...); err != nil {
+			return cluster, err
+		}
+	}
+	return cluster, nil
+}
+
+func wantedStatus(engine Engine, d *schema.ResourceData) string {
+	if normalizeStatus(engine.value(d, "status").(string)) == "STOPPED" {
+		return "STOPPED"
+	}
+	return "RUNNING"
+}
+
+// SameName tells whether two engine or plan names are the same, ignoring case
+// and surrounding spaces.
+func SameName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// SameVersion tells whether two engine versions are the same, ignoring case,
+// surrounding spaces and a leading v, e.g. "V16" and "16".
+func SameVersion(a, b string) bool {
+	trim := func(v string) string {
+		return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(v)), "v")
+	}
+	return trim(a) == trim(b)
+}
+
+// SuppressEquivalentName hides diffs between spellings of the same name, which
+// would otherwise change or replace the cluster.
+func SuppressEquivalentName(k, old, new string, d *schema.ResourceData) bool {
+	return SameName(old, new)
+}
+
+// SuppressEquivalentVersion hides diffs between spellings of the same version.
+func SuppressEquivalentVersion(k, old, new string, d *schema.ResourceData) bool {
+	return SameVersion(old, new)
+}
+
+// diskSize parses the disk of the master node, reported like "100 GB".
+func diskSize(cluster *models.DB) int {
+	fields := strings.Fields(cluster.MasterNode.Disk)
+	if len(fields) == 0 {
+		return 0
+	}
+	size, _ := strconv.Atoi(fields[0])
+	return size
+}
+
+func validateDiskSize(engine Engine, diff *schema.ResourceDiff) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	for _, key := range append([]string{"disk_size"}, aliasNames(engine.aliasesOf("disk_size"))...) {
+		if !diff.HasChange(key) {
+			continue
+		}
+		o, n := diff.GetChange(key)
+		if o.(int) != 0 && n.(int) != 0 && n.(int) < o.(int) {
+			return fmt.Errorf("%s can't shrink from %d GB to %d GB", key, o.(int), n.(int))
+		}
+	}
+	return nil
+}
+
+// validatePlan fails the plan when the wanted plan isn't offered for the
+// version, is out of stock or has no such committed SKU, instead of failing
+// the apply. Values only known at apply time are left to the API.
+func validatePlan(engine Engine, diff *schema.ResourceDiff, apiClient *client.Client) error {
+	planKeys := []string{"plan", "version"}
+	planKeys = append(planKeys, aliasNames(engine.aliasesOf("plan"))...)
+	planKeys = append(planKeys, aliasNames(engine.aliasesOf("version"))...)
+	changed := diff.Id() == ""
+	for _, key := range append([]string{"project_id", "location"}, planKeys...) {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+		changed = changed || diff.HasChange(key)
+	}
+
+	projectID := diff.Get("project_id").(string)
+	location := diff.Get("location").(string)
+	var plan *models.PlanTemplate
+	lookup := func() ([]models.PlanCommittedSKU, error) {
+		if plan == nil {
+			_, p, err := lookupPlan(apiClient, engine, diff, projectID, location)
+			if err != nil {
+				return nil, err
+			}
+			plan = p
+		}
+		return plan.CommittedSKUs, nil
+	}
+
+	if changed {
+		if _, err := lookup(); err != nil {
+			return err
+		}
+		if !plan.IsInventoryAvailable {
+			return fmt.Errorf("plan %s is out of stock in %s, pick another one from the e2e_dbaas_plans data source", plan.PlanName, location)
+		}
+	}
+	return committed.ValidateDiff(diff, planKeys, lookup)
+}
+
+// planCommittedSKUs returns a lookup of the committed SKUs of the wanted plan.
+func planCommittedSKUs(apiClient *client.Client, engine Engine, d *schema.ResourceData, projectID, location string) func() ([]models.PlanCommittedSKU, error) {
+	return func() ([]models.PlanCommittedSKU, error) {
+		_, plan, err := lookupPlan(apiClient, engine, d, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		return plan.CommittedSKUs, nil
+	}
+}
+
+func aliasNames(aliases []Alias) []string {
+	names := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		names = append(names, a.Name)
+	}
+	return names
+}
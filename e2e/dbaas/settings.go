@@ -0,0 +1,141 @@
+package dbaas
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var validateTimeOfDay = validation.StringMatch(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "must be a UTC time of day like 02:30")
+
+func maintenanceWindowSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: "Weekly window the cluster is patched in, the window picked by the platform when not set",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"day": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(weekdays, false),
+					Description:  "Day of the week, e.g. sunday",
+				},
+				"start_time": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateTimeOfDay,
+					Description:  "UTC time the window starts at, e.g. 02:30",
+				},
+			},
+		},
+	}
+}
+
+func backupSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: "Daily backup schedule of the cluster, the platform defaults when not set",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"start_time": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validateTimeOfDay,
+					Description:  "UTC time the daily backup starts at, e.g. 01:00",
+				},
+				"retention_days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of days backups are kept",
+				},
+				"point_in_time_recovery": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether the cluster can be cloned as of any time within the retention, PostgreSQL, MySQL and MariaDB only",
+				},
+			},
+		},
+	}
+}
+
+// validateBackup fails the plan when point in time recovery is enabled for
+// an engine that doesn't support it.
+func validateBackup(engine Engine, diff *schema.ResourceDiff) error {
+	if engine.PointInTimeRecovery || !diff.Get("backup.0.point_in_time_recovery").(bool) {
+		return nil
+	}
+	return fmt.Errorf("point_in_time_recovery isn't supported for %s", engine.Name)
+}
+
+func updateMaintenanceWindow(apiClient *client.Client, d *schema.ResourceData, projectID, location string) error {
+	windows := d.Get("maintenance_window").([]interface{})
+	if len(windows) == 0 || windows[0] == nil {
+		return nil
+	}
+	window := windows[0].(map[string]interface{})
+	return apiClient.UpdateDBaaSClusterMaintenanceWindow(d.Id(), &models.DBMaintenanceWindow{
+		Day:       window["day"].(string),
+		StartTime: window["start_time"].(string),
+	}, projectID, location)
+}
+
+// updateBackup applies the backup settings, arguments left out keeping
+// their current value.
+func updateBackup(apiClient *client.Client, d *schema.ResourceData, projectID, location string) error {
+	backups := d.Get("backup").([]interface{})
+	if len(backups) == 0 || backups[0] == nil {
+		return nil
+	}
+	backup := backups[0].(map[string]interface{})
+	return apiClient.UpdateDBaaSClusterBackupSettings(d.Id(), &models.DBBackupSettings{
+		StartTime:     backup["start_time"].(string),
+		RetentionDays: backup["retention_days"].(int),
+		PITREnabled:   backup["point_in_time_recovery"].(bool),
+	}, projectID, location)
+}
+
+func flattenMaintenanceWindow(window *models.DBMaintenanceWindow) []interface{} {
+	if window == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"day":        strings.ToLower(window.Day),
+		"start_time": timeOfDay(window.StartTime),
+	}}
+}
+
+func flattenBackup(settings *models.DBBackupSettings) []interface{} {
+	if settings == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"start_time":             timeOfDay(settings.StartTime),
+		"retention_days":         settings.RetentionDays,
+		"point_in_time_recovery": settings.PITREnabled,
+	}}
+}
+
+// timeOfDay drops the seconds of a time the API returns as 02:30:00.
+func timeOfDay(t string) string {
+	if len(t) > 5 {
+		return t[:5]
+	}
+	return t
+}
@@ -0,0 +1,236 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nodeTagPrefix tags the whitelisted IPs of a node with the node ID, so they
+// read back as the node they came from.
+const nodeTagPrefix = "node:"
+
+func trustedSourcesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Sources allowed to connect to the cluster. The cluster accepts connections from any IP when not set",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cidr": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsCIDR,
+					Description:  "IP range allowed to connect, e.g. 203.0.113.0/24",
+				},
+				"node_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of a node whose public and private IPs are allowed to connect",
+				},
+			},
+		},
+	}
+}
+
+// validateTrustedSources checks every trusted source sets exactly one of
+// cidr and node_id.
+func validateTrustedSources(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown("trusted_sources") {
+		return nil
+	}
+	for _, v := range diff.Get("trusted_sources").(*schema.Set).List() {
+		source := v.(map[string]interface{})
+		if (source["cidr"].(string) == "") == (source["node_id"].(string) == "") {
+			return fmt.Errorf("each trusted_sources block needs exactly one of cidr and node_id")
+		}
+	}
+	return nil
+}
+
+// expandTrustedSources resolves the trusted sources to the IPs to whitelist,
+// looking up the IPs of nodes.
+func expandTrustedSources(apiClient *client.Client, sources *schema.Set, projectID, location string) ([]models.AllowedIP, error) {
+	ips := []models.AllowedIP{}
+	for _, v := range sources.List() {
+		source := v.(map[string]interface{})
+		if cidr := source["cidr"].(string); cidr != "" {
+			ips = append(ips, models.AllowedIP{IP: cidr})
+			continue
+		}
+		nodeID := source["node_id"].(string)
+		nodeIPs, found, err := trustedNodeIPs(apiClient, nodeID, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("trusted node %s not found", nodeID)
+		}
+		if len(nodeIPs) == 0 {
+			return nil, fmt.Errorf("trusted node %s has no IP address", nodeID)
+		}
+		for _, ip := range nodeIPs {
+			ips = append(ips, models.AllowedIP{IP: ip, Tag: nodeTagPrefix + nodeID})
+		}
+	}
+	return ips, nil
+}
+
+// trustedNodeIPs looks up the current public and private IPs of a node, as
+// whitelisted. found is false when the node doesn't exist anymore.
+func trustedNodeIPs(apiClient *client.Client, nodeID, projectID, location string) (ips []string, found bool, err error) {
+	node, err := apiClient.GetNode(nodeID, projectID, location)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "status code: 404") {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to find trusted node %s: %v", nodeID, err)
+	}
+	data, ok := node["data"].(map[string]interface{})
+	if !ok {
+		return nil, false, nil
+	}
+	for _, key := range []string{"public_ip_address", "private_ip_address"} {
+		if ip, _ := data[key].(string); ip != "" {
+			ips = append(ips, ip+"/32")
+		}
+	}
+	return ips, true, nil
+}
+
+// flattenTrustedSources turns the whitelist of a cluster back into trusted
+// sources, IPs tagged with a node reading back as that node. A node whose
+// IPs changed since it was whitelisted is left out, so the next plan adds it
+// again with its current IPs. So is a node that doesn't exist anymore, for
+// the plan to remove its IPs or fail on the missing node.
+func flattenTrustedSources(apiClient *client.Client, allowed models.AllowedIPs, projectID, location string) ([]interface{}, error) {
+	sources := []interface{}{}
+	var nodes []string
+	whitelisted := map[string]map[string]bool{}
+	for i, ip := range allowed.WhitelistedIPs {
+		tag := ""
+		if i < len(allowed.WhitelistedIPsTags) {
+			tag = allowed.WhitelistedIPsTags[i]
+		}
+		if nodeID, ok := strings.CutPrefix(tag, nodeTagPrefix); ok {
+			if whitelisted[nodeID] == nil {
+				whitelisted[nodeID] = map[string]bool{}
+				nodes = append(nodes, nodeID)
+			}
+			whitelisted[nodeID][ip] = true
+			continue
+		}
+		sources = append(sources, map[string]interface{}{"cidr": ip, "node_id": ""})
+	}
+	for _, nodeID := range nodes {
+		current, found, err := trustedNodeIPs(apiClient, nodeID, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Printf("[WARN] Trusted node %s not found, removing it from the trusted sources", nodeID)
+			continue
+		}
+		if !sameIPs(whitelisted[nodeID], current) {
+			log.Printf("[INFO] IPs of trusted node %s changed, whitelisted: %v, current: %v", nodeID, whitelisted[nodeID], current)
+			continue
+		}
+		sources = append(sources, map[string]interface{}{"cidr": "", "node_id": nodeID})
+	}
+	return sources, nil
+}
+
+func sameIPs(whitelisted map[string]bool, current []string) bool {
+	if len(whitelisted) != len(current) {
+		return false
+	}
+	for _, ip := range current {
+		if !whitelisted[ip] {
+			return false
+		}
+	}
+	return true
+}
+
+// updateTrustedSources brings the whitelist of the cluster in line with the
+// trusted sources, removing IPs whitelisted outside Terraform as well.
+func updateTrustedSources(ctx context.Context, apiClient *client.Client, cluster *models.DB, sources *schema.Set, projectID, location string) error {
+	id := strconv.Itoa(cluster.ID)
+	wanted, err := expandTrustedSources(apiClient, sources, projectID, location)
+	if err != nil {
+		return err
+	}
+
+	// Entries match on IP and tag, so the stale IPs of a trusted node whose
+	// IPs changed are detached and its current IPs attached.
+	current := cluster.MasterNode.AllowedIPs
+	present := map[models.AllowedIP]bool{}
+	var whitelisted []models.AllowedIP
+	for i, ip := range current.WhitelistedIPs {
+		entry := models.AllowedIP{IP: ip}
+		if i < len(current.WhitelistedIPsTags) {
+			entry.Tag = current.WhitelistedIPsTags[i]
+		}
+		present[entry] = true
+		whitelisted = append(whitelisted, entry)
+	}
+	keep := map[models.AllowedIP]bool{}
+	var attach []models.AllowedIP
+	for _, ip := range wanted {
+		keep[ip] = true
+		if !present[ip] {
+			attach = append(attach, ip)
+		}
+	}
+	var detach []models.AllowedIP
+	for _, entry := range whitelisted {
+		if !keep[entry] {
+			detach = append(detach, entry)
+		}
+	}
+
+	if len(detach) > 0 {
+		if err := apiClient.DetachAllowedIPsFromDBaaSCluster(id, detach, projectID, location); err != nil {
+			return err
+		}
+		if err := waitForWhitelisting(ctx, apiClient, id, projectID, location); err != nil {
+			return err
+		}
+	}
+	if len(attach) > 0 {
+		if err := apiClient.AttachAllowedIPsToDBaaSCluster(id, attach, projectID, location); err != nil {
+			return err
+		}
+		if err := waitForWhitelisting(ctx, apiClient, id, projectID, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func waitForWhitelisting(ctx context.Context, apiClient *client.Client, id, projectID, location string) error {
+	for {
+		cluster, err := apiClient.GetDBaaSCluster(id, projectID, location)
+		if err != nil {
+			return fmt.Errorf("failed to fetch DBaaS cluster status: %w", err)
+		}
+		if !cluster.MasterNode.AllowedIPs.WhitelistingRunning {
+			return nil
+		}
+		log.Printf("[INFO] Whitelist of DBaaS cluster %s is being updated, waiting for it to finish", id)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the whitelist of DBaaS cluster %s to update", id)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
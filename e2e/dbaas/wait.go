@@ -0,0 +1,117 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+)
+
+// clusterSettledStatuses are the API statuses a cluster accepts changes in.
+var clusterSettledStatuses = []string{"RUNNING", "SUSPENDED"}
+
+// legacyStatuses are power actions earlier engine schemas accepted as status.
+// RESTARTING is also reported by the API while a cluster restarts.
+var legacyStatuses = map[string]string{
+	"start":      "RUNNING",
+	"stop":       "STOPPED",
+	"RESTARTING": "RUNNING",
+}
+
+func waitForClusterStatus(ctx context.Context, apiClient *client.Client, id string, projectID, location string, statuses ...string) (*models.DB, error) {
+	for {
+		cluster, err := apiClient.GetDBaaSCluster(id, projectID, location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch DBaaS cluster status: %w", err)
+		}
+		for _, status := range statuses {
+			if cluster.Status == status {
+				return cluster, nil
+			}
+		}
+		log.Printf("[INFO] DBaaS cluster %s is %s, waiting for %s", id, cluster.Status, strings.Join(statuses, " or "))
+		select {
+		case <-ctx.Done():
+			return cluster, fmt.Errorf("timed out waiting for DBaaS cluster %s to be %s, current: %s", id, strings.Join(statuses, " or "), cluster.Status)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+// WaitForClusterRunning waits for a cluster to be RUNNING, for resources
+// managing a part of a cluster.
+func WaitForClusterRunning(ctx context.Context, apiClient *client.Client, id string, projectID, location string) (*models.DB, error) {
+	return waitForClusterStatus(ctx, apiClient, id, projectID, location, "RUNNING")
+}
+
+// setClusterStatus starts or stops the cluster when it is not already in the
+// wanted status, RUNNING or STOPPED, and waits for it to get there.
+func setClusterStatus(ctx context.Context, apiClient *client.Client, cluster *models.DB, wanted string, projectID, location string) (*models.DB, error) {
+	if normalizeStatus(cluster.Status) == wanted {
+		return cluster, nil
+	}
+	id := strconv.Itoa(cluster.ID)
+	log.Printf("[INFO] Changing status of DBaaS cluster %s from %s → %s", id, cluster.Status, wanted)
+	if wanted == "STOPPED" {
+		if err := apiClient.ShutdownDBaaSCluster(id, projectID, location); err != nil {
+			return cluster, err
+		}
+		return waitForClusterStatus(ctx, apiClient, id, projectID, location, "SUSPENDED")
+	}
+	if err := apiClient.ResumeDBaaSCluster(id, projectID, location); err != nil {
+		return cluster, err
+	}
+	return waitForClusterStatus(ctx, apiClient, id, projectID, location, "RUNNING")
+}
+
+// normalizeStatus maps the SUSPENDED status of the API and the power actions
+// of earlier schemas to RUNNING and STOPPED.
+func normalizeStatus(status string) string {
+	if status == "SUSPENDED" {
+		return "STOPPED"
+	}
+	if s, ok := legacyStatuses[status]; ok {
+		return s
+	}
+	return status
+}
+
+func validateStatus(val interface{}, key string) (warns []string, errs []error) {
+	status := val.(string)
+	switch {
+	case status == "RUNNING" || status == "STOPPED":
+	case status == "restart" || status == "RESTARTING":
+		errs = append(errs, restartError(key, status))
+	case legacyStatuses[status] != "":
+		warns = append(warns, fmt.Sprintf("%q = %q is deprecated, use %q instead", key, status, legacyStatuses[status]))
+	default:
+		errs = append(errs, fmt.Errorf("expected %s to be one of [RUNNING STOPPED], got %s", key, status))
+	}
+	return
+}
+
+// restartError rejects the restart power actions of earlier schemas, which
+// restarted the cluster on every apply, rather than silently keeping it
+// running.
+func restartError(key, status string) error {
+	return fmt.Errorf("%s = %q no longer restarts the cluster, set %s to \"RUNNING\" and change restart_trigger to restart it", key, status, key)
+}
+
+// ValidatePowerAction validates the power actions earlier schemas accepted,
+// rejecting restart.
+func ValidatePowerAction(val interface{}, key string) (warns []string, errs []error) {
+	switch action := val.(string); action {
+	case "start", "stop":
+	case "restart":
+		errs = append(errs, restartError(key, action))
+	default:
+		errs = append(errs, fmt.Errorf("expected %s to be one of [start stop], got %s", key, action))
+	}
+	return
+}
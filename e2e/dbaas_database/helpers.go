@@ -0,0 +1,32 @@
+package dbaas_database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandStrings(set *schema.Set) []string {
+	values := []string{}
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+	return values
+}
+
+// importStateFunc imports a user or database of a cluster by
+// project_id/location/cluster_id/name.
+func importStateFunc(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid ID format: expected project_id/location/cluster_id/name")
+	}
+
+	d.Set("project_id", parts[0])
+	d.Set("location", parts[1])
+	d.Set("cluster_id", parts[2])
+	d.SetId(parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,145 @@
+package dbaas_database
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDatabase() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Location of the cluster",
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the DBaaS cluster",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Name of the database",
+			},
+			"character_set": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Character set of the database, the engine default when not set",
+			},
+			"collation": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Collation of the database, the engine default when not set",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "User owning the database, the admin user of the cluster when not set",
+			},
+		},
+
+		CreateContext: resourceCreateDatabase,
+		ReadContext:   resourceReadDatabase,
+		UpdateContext: resourceUpdateDatabase,
+		DeleteContext: resourceDeleteDatabase,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceCreateDatabase(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	clusterID := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	if _, err := dbaas.WaitForClusterRunning(ctx, apiClient, clusterID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := apiClient.CreateDBaaSDatabase(clusterID, &models.DBDatabaseRequest{
+		Name:         name,
+		CharacterSet: d.Get("character_set").(string),
+		Collation:    d.Get("collation").(string),
+		Owner:        d.Get("owner").(string),
+	}, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(name)
+
+	return resourceReadDatabase(ctx, d, m)
+}
+
+func resourceReadDatabase(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	database, err := apiClient.GetDBaaSDatabase(d.Get("cluster_id").(string), d.Id(), d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if database == nil {
+		log.Printf("[WARN] DBaaS database %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("name", database.Name)
+	d.Set("character_set", database.CharacterSet)
+	d.Set("collation", database.Collation)
+	d.Set("owner", database.Owner)
+	return diags
+}
+
+func resourceUpdateDatabase(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	if d.HasChange("owner") {
+		if err := apiClient.UpdateDBaaSDatabaseOwner(d.Get("cluster_id").(string), d.Id(), d.Get("owner").(string), d.Get("project_id").(string), d.Get("location").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceReadDatabase(ctx, d, m)
+}
+
+func resourceDeleteDatabase(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	if err := apiClient.DeleteDBaaSDatabase(d.Get("cluster_id").(string), d.Id(), d.Get("project_id").(string), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,156 @@
+package dbaas_database
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceUser() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Location of the cluster",
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the DBaaS cluster",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Name of the user",
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(8, 128),
+				Description:  "Password of the user, changing it rotates the password in place",
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Privileges granted to the user as the engine names them, e.g. SELECT",
+			},
+			"databases": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Databases the privileges apply to, every database when not set",
+			},
+		},
+
+		CreateContext: resourceCreateUser,
+		ReadContext:   resourceReadUser,
+		UpdateContext: resourceUpdateUser,
+		DeleteContext: resourceDeleteUser,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceCreateUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	clusterID := d.Get("cluster_id").(string)
+	username := d.Get("username").(string)
+
+	if _, err := dbaas.WaitForClusterRunning(ctx, apiClient, clusterID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := apiClient.CreateDBaaSUser(clusterID, &models.DBUserRequest{
+		Username:   username,
+		Password:   d.Get("password").(string),
+		Privileges: expandStrings(d.Get("privileges").(*schema.Set)),
+		Databases:  expandStrings(d.Get("databases").(*schema.Set)),
+	}, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(username)
+
+	return resourceReadUser(ctx, d, m)
+}
+
+func resourceReadUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	user, err := apiClient.GetDBaaSUser(d.Get("cluster_id").(string), d.Id(), d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if user == nil {
+		log.Printf("[WARN] DBaaS user %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	// The password can't be read back, it keeps the configured value.
+	d.Set("username", user.Username)
+	d.Set("privileges", user.Privileges)
+	d.Set("databases", user.Databases)
+	return diags
+}
+
+func resourceUpdateUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	clusterID := d.Get("cluster_id").(string)
+
+	if d.HasChange("password") {
+		if err := apiClient.RotateDBaaSUserPassword(clusterID, d.Id(), d.Get("password").(string), projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChanges("privileges", "databases") {
+		if err := apiClient.UpdateDBaaSUserPrivileges(clusterID, d.Id(),
+			expandStrings(d.Get("privileges").(*schema.Set)),
+			expandStrings(d.Get("databases").(*schema.Set)),
+			projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceReadUser(ctx, d, m)
+}
+
+func resourceDeleteUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	if err := apiClient.DeleteDBaaSUser(d.Get("cluster_id").(string), d.Id(), d.Get("project_id").(string), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
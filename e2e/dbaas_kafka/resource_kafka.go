@@ -0,0 +1,15 @@
+package dbaas_kafka
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var engine = dbaas.Engine{
+	Name:                   "Kafka",
+	OptionalDatabaseFields: []string{"name"},
+}
+
+func ResourceKafka() *schema.Resource {
+	return dbaas.Resource(engine)
+}
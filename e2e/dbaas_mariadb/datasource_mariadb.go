@@ -107,7 +107,7 @@ func dataSourceReadMariaDB(ctx context.Context, d *schema.ResourceData, m interf
 	location := d.Get("location").(string)
 
 	// Call API
-	maria, err := apiClient.ReadMariaDB(clusterID, projectID, location)
+	maria, err := apiClient.GetDBaaSCluster(clusterID, projectID, location)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,14 @@
+package dbaas_mongodb
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var engine = dbaas.Engine{
+	Name: "MongoDB",
+}
+
+func ResourceMongoDB() *schema.Resource {
+	return dbaas.Resource(engine)
+}
@@ -101,12 +101,12 @@ func dataSourceReadMySQL(ctx context.Context, d *schema.ResourceData, m interfac
 	projectID := d.Get("project_id").(string)
 	location := d.Get("location").(string)
 
-	res, err := apiClient.GetMySqlDbaas(dbaasID, projectID, location)
+	res, err := apiClient.GetDBaaSCluster(dbaasID, projectID, location)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while fteching dbaas instance details: %s", err))
 	}
 
-	mysql := res.Data
+	mysql := res
 	master := mysql.MasterNode
 	db := master.Database
 	plan := master.Plan
@@ -0,0 +1,333 @@
+package dbaas_parameter_group
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/node"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceParameterGroup() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Location of the parameter group",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the parameter group",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the parameter group",
+			},
+			"engine": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: dbaas.SuppressEquivalentName,
+				Description:      "Database engine of the parameter group, e.g. PostgreSQL",
+			},
+			"version": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: dbaas.SuppressEquivalentVersion,
+				Description:      "Engine version of the parameter group",
+			},
+			"parameters": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Database parameters set by the group, by name. Parameters left out keep the engine default",
+			},
+			"software_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the engine version",
+			},
+			"restart_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last parameter change needs a restart of the attached clusters to apply",
+			},
+		},
+
+		CreateContext: resourceCreateParameterGroup,
+		ReadContext:   resourceReadParameterGroup,
+		UpdateContext: resourceUpdateParameterGroup,
+		DeleteContext: resourceDeleteParameterGroup,
+		CustomizeDiff: resourceParameterGroupCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: node.CustomImportStateFunc,
+		},
+	}
+}
+
+func resourceCreateParameterGroup(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	engine := d.Get("engine").(string)
+	version := d.Get("version").(string)
+
+	softwareID, err := dbaas.FindSoftware(apiClient, engine, version, projectID, location)
+	if err != nil {
+		return diag.Errorf("failed to find %s %s: %s", engine, version, err)
+	}
+
+	group, err := apiClient.CreateDBaaSParameterGroup(&models.ParameterGroupCreateRequest{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		SoftwareID:  softwareID,
+	}, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := strconv.Itoa(group.ID)
+	d.SetId(id)
+	d.Set("software_id", softwareID)
+	d.Set("restart_required", false)
+
+	if parameters := d.Get("parameters").(map[string]interface{}); len(parameters) > 0 {
+		// The parameters of the engine are only known once the group exists.
+		group, err = apiClient.GetDBaaSParameterGroup(id, projectID, location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		changes, err := parameterChanges(group.Parameters, nil, parameters)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := apiClient.UpdateDBaaSParameterGroup(id, &models.ParameterGroupUpdateRequest{
+			Name:        group.Name,
+			Description: group.Description,
+			Parameters:  changes,
+		}, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceReadParameterGroup(ctx, d, m)
+}
+
+func resourceReadParameterGroup(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	group, err := apiClient.GetDBaaSParameterGroup(d.Id(), d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	// Keep the configured spelling, the API may report it in another case
+	if !dbaas.SameName(d.Get("engine").(string), group.Software.Name) {
+		d.Set("engine", group.Software.Name)
+	}
+	if !dbaas.SameVersion(d.Get("version").(string), group.Software.Version) {
+		d.Set("version", group.Software.Version)
+	}
+
+	// Only the parameters managed by the configuration are tracked, the group
+	// holds every parameter of its engine.
+	managed := d.Get("parameters").(map[string]interface{})
+	parameters := map[string]interface{}{}
+	for _, p := range group.Parameters {
+		if _, ok := managed[p.Name]; ok {
+			parameters[p.Name] = p.Value
+		}
+	}
+	if err := d.Set("parameters", parameters); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceUpdateParameterGroup(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	id := d.Id()
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	if d.HasChanges("name", "description", "parameters") {
+		group, err := apiClient.GetDBaaSParameterGroup(id, projectID, location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		o, n := d.GetChange("parameters")
+		changes, err := parameterChanges(group.Parameters, o.(map[string]interface{}), n.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := apiClient.UpdateDBaaSParameterGroup(id, &models.ParameterGroupUpdateRequest{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+			Parameters:  changes,
+		}, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		if d.HasChange("parameters") {
+			d.Set("restart_required", needsRestart(changes))
+		}
+	}
+
+	return resourceReadParameterGroup(ctx, d, m)
+}
+
+func resourceDeleteParameterGroup(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	if err := apiClient.DeleteDBaaSParameterGroup(d.Id(), d.Get("project_id").(string), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
+
+// resourceParameterGroupCustomizeDiff checks changed parameters of an
+// existing group against the parameters of its engine and plans whether the
+// change needs a restart. Parameters of a new group are checked on create.
+func resourceParameterGroupCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("parameters") || !diff.NewValueKnown("parameters") {
+		return nil
+	}
+	apiClient := m.(*client.Client)
+
+	group, err := apiClient.GetDBaaSParameterGroup(diff.Id(), diff.Get("project_id").(string), diff.Get("location").(string))
+	if err != nil {
+		return err
+	}
+	o, n := diff.GetChange("parameters")
+	changes, err := parameterChanges(group.Parameters, o.(map[string]interface{}), n.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("restart_required", needsRestart(changes))
+}
+
+// parameterChanges validates the wanted parameter values against the
+// parameters of the engine and returns the parameters to send, parameters
+// removed from the configuration going back to their default.
+func parameterChanges(available []models.ParameterGroupParameter, o, n map[string]interface{}) ([]models.ParameterGroupParameter, error) {
+	byName := map[string]models.ParameterGroupParameter{}
+	for _, p := range available {
+		byName[p.Name] = p
+	}
+
+	var changes []models.ParameterGroupParameter
+	var errs []string
+	for name, v := range n {
+		p, ok := byName[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s isn't a parameter of the engine", name))
+			continue
+		}
+		value := v.(string)
+		if err := validateParameter(p, value); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if old, ok := o[name]; ok && old.(string) == value {
+			continue
+		}
+		p.Value = value
+		changes = append(changes, p)
+	}
+	for name := range o {
+		if _, ok := n[name]; ok {
+			continue
+		}
+		if p, ok := byName[name]; ok {
+			p.Value = p.DefaultValue
+			changes = append(changes, p)
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, fmt.Errorf("invalid parameters:\n%s", strings.Join(errs, "\n"))
+	}
+	return changes, nil
+}
+
+var rangeRegexp = regexp.MustCompile(`^(-?[0-9.]+)-(-?[0-9.]+)$`)
+
+// validateParameter checks a value against the data type and the allowed
+// values of a parameter. Allowed values are either a range like "0-100" or a
+// comma separated list.
+func validateParameter(p models.ParameterGroupParameter, value string) error {
+	var number float64
+	var err error
+	switch strings.ToLower(p.DataType) {
+	case "integer":
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 64)
+		number = float64(n)
+	case "float":
+		number, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		switch strings.ToLower(value) {
+		case "true", "false", "on", "off", "1", "0":
+		default:
+			err = fmt.Errorf("not a boolean")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s must be of type %s, got %q", p.Name, p.DataType, value)
+	}
+
+	allowed := strings.TrimSpace(p.AllowedValues)
+	if allowed == "" {
+		return nil
+	}
+	if match := rangeRegexp.FindStringSubmatch(allowed); match != nil && p.DataType != "string" {
+		lo, _ := strconv.ParseFloat(match[1], 64)
+		hi, _ := strconv.ParseFloat(match[2], 64)
+		if number < lo || number > hi {
+			return fmt.Errorf("%s must be between %s and %s, got %s", p.Name, match[1], match[2], value)
+		}
+		return nil
+	}
+	for _, a := range strings.Split(allowed, ",") {
+		if strings.EqualFold(strings.TrimSpace(a), value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of %s, got %q", p.Name, allowed, value)
+}
+
+// needsRestart tells whether one of the changed parameters is only applied
+// on restart.
+func needsRestart(changes []models.ParameterGroupParameter) bool {
+	for _, p := range changes {
+		if strings.EqualFold(p.ApplyType, "static") {
+			return true
+		}
+	}
+	return false
+}
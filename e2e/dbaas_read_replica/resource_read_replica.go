@@ -0,0 +1,310 @@
+package dbaas_read_replica
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// replicatedEngines are the engines supporting read replicas.
+var replicatedEngines = []string{"PostgreSQL", "MySQL", "MariaDB"}
+
+func ResourceReadReplica() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Location of the cluster",
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the PostgreSQL, MySQL or MariaDB cluster to replicate",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the read replica",
+			},
+			"plan": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the plan of the replica, the plan of the cluster when not set",
+			},
+			"promote": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Promote the replica to a standalone cluster. A promoted replica can't replicate again",
+			},
+
+			"node_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Node ID of the replica",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the replica node",
+			},
+			"db_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the database on the replica",
+			},
+			"public_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public IP of the replica",
+			},
+			"private_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Private IP of the replica",
+			},
+			"port": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Port the replica listens on",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "host:port to connect to the replica, on the public IP when there is one",
+			},
+			"promoted_cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the standalone cluster the replica became when promoted",
+			},
+		},
+
+		CreateContext: resourceCreateReadReplica,
+		ReadContext:   resourceReadReadReplica,
+		UpdateContext: resourceUpdateReadReplica,
+		DeleteContext: resourceDeleteReadReplica,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+			if o, n := diff.GetChange("promote"); o.(bool) && !n.(bool) {
+				return fmt.Errorf("a promoted replica can't replicate again, replace it to create a new replica")
+			}
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			State: readReplicaImportStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceCreateReadReplica(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	clusterID := d.Get("cluster_id").(string)
+
+	cluster, err := dbaas.WaitForClusterRunning(ctx, apiClient, clusterID, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !isReplicated(cluster.Software.Name) {
+		return diag.Errorf("DBaaS cluster %s runs %s, read replicas are only supported for %s", clusterID, cluster.Software.Name, strings.Join(replicatedEngines, ", "))
+	}
+
+	req := models.DBReplicaCreateRequest{Name: d.Get("name").(string)}
+	if plan := d.Get("plan").(string); plan != "" {
+		softwareID, err := apiClient.GetSoftwareId(projectID, location, cluster.Software.Name, cluster.Software.Version)
+		if err != nil {
+			return diag.Errorf("failed to find %s %s: %s", cluster.Software.Name, cluster.Software.Version, err)
+		}
+		if req.TemplateID, err = apiClient.GetTemplateId(projectID, location, plan, strconv.Itoa(softwareID)); err != nil {
+			return diag.Errorf("failed to find plan %s: %s", plan, err)
+		}
+	}
+
+	replica, err := apiClient.CreateDBaaSReplica(clusterID, &req, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := strconv.Itoa(replica.NodeID)
+	d.SetId(id)
+	log.Printf("[INFO] Read replica %s of DBaaS cluster %s created, waiting for replication", id, clusterID)
+
+	if _, err := waitForReplication(ctx, apiClient, clusterID, id, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get("promote").(bool) {
+		if err := promoteReplica(ctx, apiClient, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceReadReadReplica(ctx, d, m)
+}
+
+func resourceReadReadReplica(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	var node *models.DBNode
+	if promotedID := d.Get("promoted_cluster_id").(string); promotedID != "" {
+		cluster, err := apiClient.GetDBaaSCluster(promotedID, projectID, location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		node = &cluster.MasterNode
+	} else {
+		replica, err := apiClient.GetDBaaSReplica(d.Get("cluster_id").(string), d.Id(), projectID, location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if replica == nil {
+			log.Printf("[WARN] Read replica %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		node = replica
+	}
+
+	host := node.PublicIPAddress
+	if host == "" {
+		host = node.PrivateIPAddress
+	}
+	d.Set("node_id", node.NodeID)
+	d.Set("status", node.Status)
+	d.Set("db_status", node.DBStatus)
+	d.Set("public_ip_address", node.PublicIPAddress)
+	d.Set("private_ip_address", node.PrivateIPAddress)
+	d.Set("port", node.Port)
+	d.Set("endpoint", host+":"+node.Port)
+
+	return diags
+}
+
+func resourceUpdateReadReplica(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	if d.HasChange("promote") && d.Get("promote").(bool) {
+		if err := promoteReplica(ctx, apiClient, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceReadReadReplica(ctx, d, m)
+}
+
+// resourceDeleteReadReplica deletes the replica, or the cluster it became
+// when promoted.
+func resourceDeleteReadReplica(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	var err error
+	if promotedID := d.Get("promoted_cluster_id").(string); promotedID != "" {
+		err = apiClient.DeleteDBaaSCluster(promotedID, projectID, location)
+	} else {
+		err = apiClient.DeleteDBaaSReplica(d.Get("cluster_id").(string), d.Id(), projectID, location)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
+
+func promoteReplica(ctx context.Context, apiClient *client.Client, d *schema.ResourceData) error {
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+
+	cluster, err := apiClient.PromoteDBaaSReplica(d.Get("cluster_id").(string), d.Id(), projectID, location)
+	if err != nil {
+		return err
+	}
+	promotedID := strconv.Itoa(cluster.ID)
+	d.Set("promoted_cluster_id", promotedID)
+	_, err = dbaas.WaitForClusterRunning(ctx, apiClient, promotedID, projectID, location)
+	return err
+}
+
+// waitForReplication waits for the replica node to run and its database to
+// replicate, failing when either fails.
+func waitForReplication(ctx context.Context, apiClient *client.Client, clusterID, id, projectID, location string) (*models.DBNode, error) {
+	for {
+		replica, err := apiClient.GetDBaaSReplica(clusterID, id, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		if replica == nil {
+			return nil, fmt.Errorf("read replica %s of DBaaS cluster %s disappeared", id, clusterID)
+		}
+		for _, status := range []string{replica.Status, replica.DBStatus} {
+			if strings.EqualFold(status, "failed") || strings.EqualFold(status, "error") {
+				return replica, fmt.Errorf("read replica %s of DBaaS cluster %s failed: %s", id, clusterID, status)
+			}
+		}
+		if strings.EqualFold(replica.Status, "running") && (replica.DBStatus == "" || strings.EqualFold(replica.DBStatus, "running")) {
+			return replica, nil
+		}
+		log.Printf("[INFO] Read replica %s is %s (database %s), waiting for replication", id, replica.Status, replica.DBStatus)
+		select {
+		case <-ctx.Done():
+			return replica, fmt.Errorf("timed out waiting for read replica %s to replicate, current: %s (database %s)", id, replica.Status, replica.DBStatus)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+func isReplicated(software string) bool {
+	for _, engine := range replicatedEngines {
+		if strings.EqualFold(engine, software) {
+			return true
+		}
+	}
+	return false
+}
+
+func readReplicaImportStateFunc(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid ID format: expected project_id/location/cluster_id/node_id")
+	}
+
+	d.Set("project_id", parts[0])
+	d.Set("location", parts[1])
+	d.Set("cluster_id", parts[2])
+	d.SetId(parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
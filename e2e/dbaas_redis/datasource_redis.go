@@ -0,0 +1,10 @@
+package dbaas_redis
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRedis() *schema.Resource {
+	return dbaas.DataSource(engine)
+}
@@ -0,0 +1,17 @@
+package dbaas_redis
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Newer locations list Redis compatible clusters as Valkey.
+var engine = dbaas.Engine{
+	Name:                   "Redis",
+	SoftwareNames:          []string{"Valkey", "Redis"},
+	OptionalDatabaseFields: []string{"user", "name"},
+}
+
+func ResourceRedis() *schema.Resource {
+	return dbaas.Resource(engine)
+}
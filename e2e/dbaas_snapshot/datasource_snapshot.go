@@ -0,0 +1,91 @@
+package dbaas_snapshot
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceReadSnapshot,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Location of the cluster",
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the DBaaS cluster the snapshot belongs to",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the snapshot. The most recent snapshot of the cluster is returned when not set",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the snapshot",
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Size of the snapshot",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time of the snapshot",
+			},
+		},
+	}
+}
+
+func dataSourceReadSnapshot(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	clusterID := d.Get("cluster_id").(string)
+	snapshots, err := apiClient.ListDBaaSSnapshots(clusterID, d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Snapshot IDs grow with time, the highest one is the most recent.
+	name := d.Get("name").(string)
+	found := -1
+	for i, snapshot := range snapshots {
+		if name != "" && snapshot.Name != name {
+			continue
+		}
+		if found == -1 || snapshot.ID > snapshots[found].ID {
+			found = i
+		}
+	}
+	if found == -1 {
+		if name != "" {
+			return diag.Errorf("DBaaS cluster %s has no snapshot named %s", clusterID, name)
+		}
+		return diag.Errorf("DBaaS cluster %s has no snapshots", clusterID)
+	}
+	snapshot := snapshots[found]
+
+	d.SetId(strconv.Itoa(snapshot.ID))
+	d.Set("name", snapshot.Name)
+	d.Set("status", snapshot.Status)
+	d.Set("size", snapshot.Size)
+	d.Set("created_at", snapshot.CreatedAt)
+	return diags
+}
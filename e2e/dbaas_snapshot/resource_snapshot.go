@@ -0,0 +1,180 @@
+package dbaas_snapshot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project ID",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Location of the cluster",
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the DBaaS cluster to snapshot",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the snapshot",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the snapshot",
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Size of the snapshot",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time of the snapshot",
+			},
+		},
+
+		CreateContext: resourceCreateSnapshot,
+		ReadContext:   resourceReadSnapshot,
+		DeleteContext: resourceDeleteSnapshot,
+		Importer: &schema.ResourceImporter{
+			State: snapshotImportStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceCreateSnapshot(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+	location := d.Get("location").(string)
+	clusterID := d.Get("cluster_id").(string)
+
+	if _, err := dbaas.WaitForClusterRunning(ctx, apiClient, clusterID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	snapshot, err := apiClient.CreateDBaaSSnapshot(clusterID, d.Get("name").(string), projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := strconv.Itoa(snapshot.ID)
+	d.SetId(id)
+
+	if _, err := waitForSnapshot(ctx, apiClient, clusterID, id, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceReadSnapshot(ctx, d, m)
+}
+
+func resourceReadSnapshot(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	snapshot, err := apiClient.GetDBaaSSnapshot(d.Get("cluster_id").(string), d.Id(), d.Get("project_id").(string), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if snapshot == nil {
+		log.Printf("[WARN] DBaaS snapshot %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	d.Set("name", snapshot.Name)
+	d.Set("status", snapshot.Status)
+	d.Set("size", snapshot.Size)
+	d.Set("created_at", snapshot.CreatedAt)
+	return diags
+}
+
+func resourceDeleteSnapshot(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	if err := apiClient.DeleteDBaaSSnapshot(d.Get("cluster_id").(string), d.Id(), d.Get("project_id").(string), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
+
+// snapshotInProgress are the statuses of a snapshot still being taken, any
+// other status ends the wait.
+var snapshotInProgress = []string{"", "creating", "pending", "queued", "in progress", "in-progress", "processing", "running"}
+
+// waitForSnapshot waits for a snapshot to finish, failing when the snapshot
+// ends in a status other than available, completed or success.
+func waitForSnapshot(ctx context.Context, apiClient *client.Client, clusterID, id, projectID, location string) (*models.DBSnapshot, error) {
+	for {
+		snapshot, err := apiClient.GetDBaaSSnapshot(clusterID, id, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil {
+			return nil, fmt.Errorf("DBaaS snapshot %s of cluster %s disappeared", id, clusterID)
+		}
+		switch status := strings.ToLower(strings.TrimSpace(snapshot.Status)); {
+		case status == "available" || status == "completed" || status == "success":
+			return snapshot, nil
+		case !isSnapshotInProgress(status):
+			return snapshot, fmt.Errorf("DBaaS snapshot %s of cluster %s ended with status %s", id, clusterID, snapshot.Status)
+		}
+		log.Printf("[INFO] DBaaS snapshot %s is %s, waiting for it to finish", id, snapshot.Status)
+		select {
+		case <-ctx.Done():
+			return snapshot, fmt.Errorf("timed out waiting for DBaaS snapshot %s, current: %s", id, snapshot.Status)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+func isSnapshotInProgress(status string) bool {
+	for _, s := range snapshotInProgress {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func snapshotImportStateFunc(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid ID format: expected project_id/location/cluster_id/snapshot_id")
+	}
+
+	d.Set("project_id", parts[0])
+	d.Set("location", parts[1])
+	d.Set("cluster_id", parts[2])
+	d.SetId(parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceKubernetesConfig() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Kubernetes cluster",
+			},
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the project. It should be unique",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Location of the Kubernetes cluster",
+			},
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Kubeconfig YAML of the cluster",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Endpoint of the Kubernetes API server",
+			},
+			"cluster_ca_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded CA certificate of the Kubernetes API server",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token used to authenticate against the Kubernetes API server",
+			},
+		},
+		ReadContext: dataSourceReadKubernetesConfig,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func dataSourceReadKubernetesConfig(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	clusterID := d.Get("cluster_id").(string)
+	// The cluster may still be being created in the same apply
+	credentials, err := waitForKubernetesCredentials(ctx, apiClient, clusterID, d.Get("project_id").(int), d.Get("location").(string))
+	if err != nil {
+		return diag.Errorf("error getting kubeconfig of cluster %s: %s", clusterID, err)
+	}
+	d.SetId(clusterID)
+	setKubernetesCredentials(d, credentials)
+	return diags
+}
@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceKubernetesVersions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the project. It should be unique",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Location to list the Kubernetes versions of",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Kubernetes versions available in the location, oldest first",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"latest_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Latest Kubernetes version available in the location",
+			},
+		},
+		ReadContext: dataSourceReadKubernetesVersions,
+	}
+}
+
+func dataSourceReadKubernetesVersions(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	plans, err := getKubernetesMasterPlans(apiClient, d.Get("project_id").(int), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	uniqueVersions := make(map[string]bool)
+	versions := make([]string, 0, len(plans))
+	for _, planData := range plans {
+		version, ok := planData["k8s_version"].(string)
+		if !ok || uniqueVersions[version] {
+			continue
+		}
+		uniqueVersions[version] = true
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareKubernetesVersions(versions[i], versions[j]) < 0
+	})
+
+	d.SetId(fmt.Sprintf("%d/%s", d.Get("project_id").(int), d.Get("location").(string)))
+	d.Set("versions", versions)
+	if len(versions) > 0 {
+		d.Set("latest_version", versions[len(versions)-1])
+	}
+	return diags
+}
@@ -1,11 +1,22 @@
 package kubernetes
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
 )
 
 func ExpandNodePools(config []interface{}, apiClient *client.Client, project_id int, location string) ([]models.NodePool, error) {
@@ -14,6 +25,7 @@ func ExpandNodePools(config []interface{}, apiClient *client.Client, project_id
 
 	for _, np := range config {
 		nodePoolDetail := np.(map[string]interface{})
+		expandNodePoolPolicies(nodePoolDetail)
 		name := nodePoolDetail["name"].(string)
 		uniqueNodePoolNames[name] = true
 		workerPlans, err := apiClient.GetKubernetesWorkerPlans(project_id, location) //Here we are are tryig to get all worker plans
@@ -358,6 +370,7 @@ func ExpandNPUpdate(nodePoolDetail map[string]interface{}, apiClient *client.Cli
 	if _, ok := nodePoolDetail["node_pool_type"]; !ok {
 		return nodeUpdate, fmt.Errorf("node_pool_type is required")
 	}
+	expandNodePoolPolicies(nodePoolDetail)
 	var policyType, customParamName, customParamValue string
 	var elasticity_policies []models.ElasticityPolicy
 	var scheduled_policies []models.SchedulePolicy
@@ -509,3 +522,786 @@ func UpdateScheduledWorker(config map[string]interface{}, min_vms int, max_vms i
 
 	return scheduledPolicies, nil
 }
+
+// nodePoolHash keys the node_pools set on the pool name, so pools are matched by
+// name instead of by position and a changed pool shows up as an in-place update.
+func nodePoolHash(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["name"].(string))
+}
+
+func nodePoolsByName(config []interface{}) map[string]map[string]interface{} {
+	nodePools := make(map[string]map[string]interface{}, len(config))
+	for _, np := range config {
+		nodePoolDetail := np.(map[string]interface{})
+		nodePools[nodePoolDetail["name"].(string)] = nodePoolDetail
+	}
+	return nodePools
+}
+
+// nodePoolSize returns the number of worker nodes the pool is expected to run.
+func nodePoolSize(nodePoolDetail map[string]interface{}) int {
+	if size, _ := nodePoolDetail["node_pool_size"].(int); size != 0 {
+		return size
+	}
+	if nodePoolDetail["node_pool_type"].(string) == "Static" {
+		return nodePoolDetail["worker_node"].(int)
+	}
+	if cardinality, _ := nodePoolDetail["cardinality"].(int); cardinality != 0 {
+		return cardinality
+	}
+	return nodePoolDetail["min_vms"].(int)
+}
+
+// nodePoolResizeTarget returns the new size of the pool and whether it has to be
+// resized. Autoscale pools are only resized when node_pool_size is set.
+func nodePoolResizeTarget(oldNodePool, newNodePool map[string]interface{}) (int, bool) {
+	if newNodePool["node_pool_type"].(string) == "Autoscale" {
+		if size, _ := newNodePool["node_pool_size"].(int); size == 0 {
+			return nodePoolSize(oldNodePool), false
+		}
+	}
+	size := nodePoolSize(newNodePool)
+	return size, size != nodePoolSize(oldNodePool)
+}
+
+func nodePoolDetailsChanged(oldNodePool, newNodePool map[string]interface{}) bool {
+	keys := []string{"specs_name"}
+	if newNodePool["node_pool_type"].(string) == "Autoscale" {
+		keys = append(keys, "min_vms", "max_vms", "elasticity_dict", "scheduled_dict", "elasticity_policy", "scheduled_policy")
+	}
+	for _, key := range keys {
+		if !reflect.DeepEqual(oldNodePool[key], newNodePool[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNodePoolChange checks a planned node pool against the previous one, so
+// invalid sizes and policies are reported at plan time instead of by the API.
+func validateNodePoolChange(oldNodePool, newNodePool map[string]interface{}) error {
+	name := newNodePool["name"].(string)
+	nodePoolType := newNodePool["node_pool_type"].(string)
+	if oldNodePool != nil && oldNodePool["node_pool_type"].(string) != nodePoolType {
+		return fmt.Errorf("node_pool_type of node pool %s cannot be changed, use a new node pool name instead", name)
+	}
+	elasticityPolicy, _ := newNodePool["elasticity_policy"].([]interface{})
+	scheduledPolicy, _ := newNodePool["scheduled_policy"].([]interface{})
+	elasticityDict, _ := newNodePool["elasticity_dict"].([]interface{})
+	scheduledDict, _ := newNodePool["scheduled_dict"].([]interface{})
+	if len(elasticityPolicy) > 0 && len(elasticityDict) > 0 {
+		return fmt.Errorf("node pool %s: elasticity_policy and elasticity_dict cannot be used together", name)
+	}
+	if len(scheduledPolicy) > 0 && len(scheduledDict) > 0 {
+		return fmt.Errorf("node pool %s: scheduled_policy and scheduled_dict cannot be used together", name)
+	}
+
+	minVms, _ := newNodePool["min_vms"].(int)
+	maxVms, _ := newNodePool["max_vms"].(int)
+	if nodePoolType == "Static" {
+		if len(elasticityPolicy) > 0 || len(scheduledPolicy) > 0 || len(elasticityDict) > 0 || len(scheduledDict) > 0 {
+			return fmt.Errorf("node pool %s: policies can only be used with Autoscale node pools", name)
+		}
+	} else {
+		if minVms == 0 || maxVms == 0 {
+			return fmt.Errorf("node pool %s: min_vms and max_vms are required for Autoscale node pools", name)
+		}
+		if minVms > maxVms {
+			return fmt.Errorf("node pool %s: min_vms (%d) cannot be greater than max_vms (%d)", name, minVms, maxVms)
+		}
+	}
+
+	for _, ep := range elasticityPolicy {
+		elasticityPolicyDetail := ep.(map[string]interface{})
+		customParameterName, _ := elasticityPolicyDetail["custom_parameter_name"].(string)
+		if elasticityPolicyDetail["parameter"].(string) == "Custom" && customParameterName == "" {
+			return fmt.Errorf("node pool %s: custom_parameter_name is required when parameter is Custom", name)
+		}
+		if elasticityPolicyDetail["parameter"].(string) != "Custom" && customParameterName != "" {
+			return fmt.Errorf("node pool %s: custom_parameter_name can only be used when parameter is Custom", name)
+		}
+	}
+	for _, sp := range scheduledPolicy {
+		scheduledPolicyDetail := sp.(map[string]interface{})
+		for _, key := range []string{"upscale_cardinality", "downscale_cardinality"} {
+			cardinality := scheduledPolicyDetail[key].(int)
+			if cardinality < minVms || cardinality > maxVms {
+				return fmt.Errorf("node pool %s: %s (%d) must be between min_vms (%d) and max_vms (%d)", name, key, cardinality, minVms, maxVms)
+			}
+		}
+	}
+
+	size, _ := newNodePool["node_pool_size"].(int)
+	if size == 0 {
+		return nil
+	}
+	if size < 2 {
+		return fmt.Errorf("node_pool_size of node pool %s cannot be less than 2", name)
+	}
+	if nodePoolType == "Autoscale" && (size < minVms || size > maxVms) {
+		return fmt.Errorf("node_pool_size of node pool %s must be between min_vms (%d) and max_vms (%d)", name, minVms, maxVms)
+	}
+	return nil
+}
+
+// expandNodePoolPolicies translates the elasticity_policy and scheduled_policy
+// blocks into the elasticity_dict and scheduled_dict shape the API expects.
+func expandNodePoolPolicies(nodePoolDetail map[string]interface{}) {
+	if elasticityPolicy, _ := nodePoolDetail["elasticity_policy"].([]interface{}); len(elasticityPolicy) > 0 {
+		elasticityPolicyDetail := elasticityPolicy[0].(map[string]interface{})
+		policyParameterType := "Default"
+		parameter := elasticityPolicyDetail["parameter"].(string)
+		if parameter == "Custom" {
+			policyParameterType = "Custom"
+			parameter = elasticityPolicyDetail["custom_parameter_name"].(string)
+		}
+		// The scale up rule has to come first, the adjust value of the rules alternates between 1 and -1
+		rules := make([]interface{}, 0, 2)
+		for _, key := range []string{"scale_up", "scale_down"} {
+			for _, rule := range elasticityPolicyDetail[key].([]interface{}) {
+				rules = append(rules, rule)
+			}
+		}
+		nodePoolDetail["elasticity_dict"] = []interface{}{
+			map[string]interface{}{
+				"worker": []interface{}{
+					map[string]interface{}{
+						"period_number":        elasticityPolicyDetail["period_number"],
+						"policy_paramter_type": policyParameterType,
+						"parameter":            parameter,
+						"elasticity_policies":  rules,
+					},
+				},
+			},
+		}
+	}
+	if scheduledPolicy, _ := nodePoolDetail["scheduled_policy"].([]interface{}); len(scheduledPolicy) > 0 {
+		nodePoolDetail["scheduled_dict"] = []interface{}{
+			map[string]interface{}{
+				"worker": []interface{}{
+					map[string]interface{}{
+						"scheduled_policies": scheduledPolicy,
+					},
+				},
+			},
+		}
+	}
+}
+
+// cronFieldBounds are the bounds of the minute, hour, day of month, month and
+// day of week fields of a cron expression.
+var cronFieldBounds = [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+func validateCronExpression(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		return ws, append(es, fmt.Errorf("expected %s to be string", k))
+	}
+	fields := strings.Fields(value)
+	if len(fields) != len(cronFieldBounds) {
+		return ws, append(es, fmt.Errorf("%s must be a cron expression with 5 fields (minute hour day month weekday), got %q", k, value))
+	}
+	for i, field := range fields {
+		if err := validateCronField(field, cronFieldBounds[i][0], cronFieldBounds[i][1]); err != nil {
+			es = append(es, fmt.Errorf("%s: invalid field %q in %q: %s", k, field, value, err))
+		}
+	}
+	return ws, es
+}
+
+func validateCronField(field string, min int, max int) error {
+	for _, item := range strings.Split(field, ",") {
+		rangePart := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			step, err := strconv.Atoi(item[i+1:])
+			if err != nil || step < 1 {
+				return fmt.Errorf("invalid step %q", item[i+1:])
+			}
+			rangePart = item[:i]
+		}
+		if rangePart == "*" {
+			continue
+		}
+		bounds := strings.SplitN(rangePart, "-", 2)
+		values := make([]int, 0, len(bounds))
+		for _, bound := range bounds {
+			value, err := strconv.Atoi(bound)
+			if err != nil {
+				return fmt.Errorf("%q is not a number", bound)
+			}
+			if value < min || value > max {
+				return fmt.Errorf("%d is out of range %d-%d", value, min, max)
+			}
+			values = append(values, value)
+		}
+		if len(values) == 2 && values[0] > values[1] {
+			return fmt.Errorf("range %q is reversed", rangePart)
+		}
+	}
+	return nil
+}
+
+func addNodePool(apiClient *client.Client, nodePoolDetail map[string]interface{}, clusterID string, project_id int, location string) error {
+	nodePoolsDetail, err := ExpandNodePools([]interface{}{nodePoolDetail}, apiClient, project_id, location)
+	if err != nil {
+		return err
+	}
+	nodePoolAdd := models.NodePoolAdd{NodePools: nodePoolsDetail}
+	response, err := apiClient.AddNodePool(&nodePoolAdd, clusterID, project_id, location)
+	if err != nil {
+		return err
+	}
+	if _, codeOK := response["code"]; !codeOK {
+		return fmt.Errorf("error adding node pool %s: %v", nodePoolDetail["name"], response["message"])
+	}
+	return nil
+}
+
+// updateNodePool resizes the pool and updates its plan and policies in place.
+func updateNodePool(apiClient *client.Client, oldNodePool, newNodePool map[string]interface{}, serviceID float64, project_id int, location string) error {
+	name := newNodePool["name"].(string)
+	if err := validateNodePoolChange(oldNodePool, newNodePool); err != nil {
+		return err
+	}
+	size, resize := nodePoolResizeTarget(oldNodePool, newNodePool)
+	newNodePool["cardinality"] = size
+	if resize {
+		log.Printf("[INFO] Resizing node pool %s from %d to %d nodes", name, nodePoolSize(oldNodePool), size)
+		nodePoolResize := models.NodePoolResize{
+			NodePoolSize: size,
+		}
+		response, err := apiClient.UpdateNodePoolCardinality(&nodePoolResize, serviceID, project_id, location)
+		if err != nil {
+			if len(response) > 0 {
+				return fmt.Errorf("error resizing node pool %s: %v", name, response["errors"])
+			}
+			return err
+		}
+	}
+	if !nodePoolDetailsChanged(oldNodePool, newNodePool) {
+		return nil
+	}
+	log.Printf("[INFO] Updating details of node pool %s", name)
+	nodePoolObject, err := ExpandNPUpdate(newNodePool, apiClient, project_id, location)
+	if err != nil {
+		return err
+	}
+	if newNodePool["node_pool_type"].(string) == "Static" {
+		nodePoolObject.MinVms = size
+		nodePoolObject.MaxVms = size
+		nodePoolObject.Cardinality = size
+	}
+	response, err := apiClient.UpdateNodePoolDetails(&nodePoolObject, serviceID, project_id, location)
+	if err != nil {
+		return err
+	}
+	if _, codeOK := response["code"]; !codeOK {
+		return fmt.Errorf("error updating node pool %s: %v", name, response["message"])
+	}
+	return nil
+}
+
+func deleteNodePool(apiClient *client.Client, clusterID string, serviceID float64, project_id int, location string) error {
+	nodePools, err := apiClient.CheckNodePoolStatus(clusterID, project_id, location)
+	if err != nil {
+		return fmt.Errorf("error finding node pools of cluster %s: %s", clusterID, err)
+	}
+	if !IsNodePoolRunning(serviceID, nodePools["data"].([]interface{})) {
+		return fmt.Errorf("You can delete a Node Pool once it comes to the running state")
+	}
+	response, err := apiClient.DeleteNodePool(serviceID, project_id, location)
+	if err != nil {
+		if len(response) > 0 {
+			return fmt.Errorf("error deleting node pool: %v", response["Status"])
+		}
+		return err
+	}
+	return nil
+}
+
+// flattenNodePools refreshes every pool in state from the API and drops the
+// pools that no longer exist on the cluster, so they are planned to be added again.
+func flattenNodePools(apiClient *client.Client, nodePools []interface{}, serviceMapping map[string]interface{}, project_id int, location string) ([]interface{}, error) {
+	flattened := make([]interface{}, 0, len(nodePools))
+	for _, np := range nodePools {
+		nodePoolDetail := np.(map[string]interface{})
+		serviceID, ok := serviceMapping[nodePoolDetail["name"].(string)].(float64)
+		if !ok {
+			log.Printf("[INFO] Node pool %s not found on the cluster", nodePoolDetail["name"])
+			continue
+		}
+		nodePoolDetail["service_id"] = fmt.Sprintf("%.0f", serviceID)
+		if err := refreshNodePool(apiClient, nodePoolDetail, serviceID, project_id, location); err != nil {
+			return nil, err
+		}
+		flattened = append(flattened, nodePoolDetail)
+	}
+	return flattened, nil
+}
+
+// refreshNodePool overwrites the size, specs and policies of a pool with the
+// ones the API reports, so changes made outside of Terraform show up in the plan.
+func refreshNodePool(apiClient *client.Client, nodePoolDetail map[string]interface{}, serviceID float64, project_id int, location string) error {
+	response, err := apiClient.GetKubernetesNodePoolDetail(serviceID, project_id, location)
+	if err != nil {
+		return fmt.Errorf("error getting details of node pool %.0f: %s", serviceID, err)
+	}
+	data, ok := response["data"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected response format for node pool %.0f: %+v", serviceID, response)
+	}
+	flattenNodePool(nodePoolDetail, data)
+	return nil
+}
+
+// flattenNodePool copies the node pool detail returned by the API into the
+// node pool. Fields the API leaves out keep their previous value.
+func flattenNodePool(nodePoolDetail map[string]interface{}, data map[string]interface{}) {
+	for _, key := range []string{"node_pool_type", "specs_name", "sku_id", "slug_name", "policy_type", "custom_param_name", "custom_param_value"} {
+		if value, ok := data[key].(string); ok && value != "" {
+			nodePoolDetail[key] = value
+		}
+	}
+	nodePoolType, _ := nodePoolDetail["node_pool_type"].(string)
+	if cardinality, ok := data["cardinality"].(float64); ok {
+		nodePoolDetail["cardinality"] = int(cardinality)
+		if size, _ := nodePoolDetail["node_pool_size"].(int); size != 0 {
+			nodePoolDetail["node_pool_size"] = int(cardinality)
+		} else if nodePoolType == "Static" {
+			nodePoolDetail["worker_node"] = int(cardinality)
+		}
+	}
+	if nodePoolType != "Autoscale" {
+		return
+	}
+	if minVms, ok := data["min_vms"].(float64); ok {
+		nodePoolDetail["min_vms"] = int(minVms)
+	}
+	if maxVms, ok := data["max_vms"].(float64); ok {
+		nodePoolDetail["max_vms"] = int(maxVms)
+	}
+	// Pools still configured with the deprecated dicts keep them as they are
+	if elasticityDict, _ := nodePoolDetail["elasticity_dict"].([]interface{}); len(elasticityDict) == 0 {
+		if policies, ok := data["elasticity_policies"].([]interface{}); ok {
+			nodePoolDetail["elasticity_policy"] = flattenElasticityPolicy(policies, nodePoolDetail["elasticity_policy"])
+		}
+	}
+	if scheduledDict, _ := nodePoolDetail["scheduled_dict"].([]interface{}); len(scheduledDict) == 0 {
+		if policies, ok := data["scheduled_policies"].([]interface{}); ok {
+			nodePoolDetail["scheduled_policy"] = flattenScheduledPolicy(policies)
+		}
+	}
+}
+
+// flattenElasticityPolicy turns the elasticity policies of the API back into an
+// elasticity_policy block. The rules with a positive adjust value scale up. The
+// period number is not returned by the API, so it is kept from the prior block.
+func flattenElasticityPolicy(policies []interface{}, prior interface{}) []interface{} {
+	if len(policies) == 0 {
+		return []interface{}{}
+	}
+	elasticityPolicyDetail := map[string]interface{}{
+		"parameter":             "CPU",
+		"custom_parameter_name": "",
+		"scale_up":              []interface{}{},
+		"scale_down":            []interface{}{},
+	}
+	if priorPolicy, _ := prior.([]interface{}); len(priorPolicy) > 0 && priorPolicy[0] != nil {
+		elasticityPolicyDetail["period_number"] = priorPolicy[0].(map[string]interface{})["period_number"]
+	}
+	for _, p := range policies {
+		policy, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if parameter, _ := policy["parameter"].(string); parameter == "CPU" || parameter == "Memory" {
+			elasticityPolicyDetail["parameter"] = parameter
+		} else if parameter != "" {
+			elasticityPolicyDetail["parameter"] = "Custom"
+			elasticityPolicyDetail["custom_parameter_name"] = parameter
+		}
+		value, _ := policy["value"].(float64)
+		period, _ := policy["period"].(float64)
+		watchPeriod, _ := policy["period_number"].(float64)
+		cooldown, _ := policy["cooldown"].(float64)
+		operator, _ := policy["operator"].(string)
+		rule := map[string]interface{}{
+			"operator":     operator,
+			"value":        int(value),
+			"period":       int(period),
+			"watch_period": int(watchPeriod),
+			"cooldown":     int(cooldown),
+		}
+		key := "scale_down"
+		if adjust, _ := policy["adjust"].(float64); adjust > 0 {
+			key = "scale_up"
+		}
+		elasticityPolicyDetail[key] = []interface{}{rule}
+		if _, ok := elasticityPolicyDetail["period_number"]; !ok {
+			elasticityPolicyDetail["period_number"] = int(watchPeriod)
+		}
+	}
+	return []interface{}{elasticityPolicyDetail}
+}
+
+// flattenScheduledPolicy turns the scheduled policies of the API, which come in
+// upscale and downscale pairs, back into a scheduled_policy block.
+func flattenScheduledPolicy(policies []interface{}) []interface{} {
+	if len(policies) < 2 {
+		return []interface{}{}
+	}
+	upscale, _ := policies[0].(map[string]interface{})
+	downscale, _ := policies[1].(map[string]interface{})
+	if upscale == nil || downscale == nil {
+		return []interface{}{}
+	}
+	upscaleCardinality, _ := upscale["adjust"].(float64)
+	downscaleCardinality, _ := downscale["adjust"].(float64)
+	return []interface{}{
+		map[string]interface{}{
+			"upscale_cardinality":   int(upscaleCardinality),
+			"upscale_recurrence":    upscale["recurrence"],
+			"downscale_cardinality": int(downscaleCardinality),
+			"downscale_recurrence":  downscale["recurrence"],
+		},
+	}
+}
+
+// kubernetesCredentials holds what is needed to reach the API server of a cluster.
+type kubernetesCredentials struct {
+	Kubeconfig           string
+	Endpoint             string
+	ClusterCACertificate string
+	Token                string
+}
+
+// errKubeconfigNotAvailable is returned while the kubeconfig of a cluster is
+// still being generated.
+var errKubeconfigNotAvailable = errors.New("kubeconfig is not available yet")
+
+// kubeconfig is the part of a kubeconfig needed to reach the API server of its
+// current context.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+func getKubernetesCredentials(apiClient *client.Client, clusterID string, location string, project_id int) (kubernetesCredentials, error) {
+	credentials := kubernetesCredentials{}
+	response, err := apiClient.GetKubernetesKubeconfig(clusterID, location, project_id)
+	if err != nil {
+		return credentials, err
+	}
+	switch data := response["data"].(type) {
+	case string:
+		credentials.Kubeconfig = data
+	case map[string]interface{}:
+		credentials.Kubeconfig, _ = data["kubeconfig"].(string)
+	}
+	if credentials.Kubeconfig == "" {
+		return credentials, errKubeconfigNotAvailable
+	}
+	if err := parseKubeconfig(&credentials); err != nil {
+		return credentials, fmt.Errorf("invalid kubeconfig of cluster %s: %s", clusterID, err)
+	}
+	return credentials, nil
+}
+
+// parseKubeconfig fills in the endpoint, CA certificate and token of the
+// cluster and user named by the current context of the kubeconfig.
+func parseKubeconfig(credentials *kubernetesCredentials) error {
+	var config kubeconfig
+	if err := yaml.Unmarshal([]byte(credentials.Kubeconfig), &config); err != nil {
+		return err
+	}
+	contextName := config.CurrentContext
+	if contextName == "" && len(config.Contexts) == 1 {
+		contextName = config.Contexts[0].Name
+	}
+	var clusterName, userName string
+	found := false
+	for _, c := range config.Contexts {
+		if c.Name == contextName {
+			clusterName, userName, found = c.Context.Cluster, c.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("context %q not found", contextName)
+	}
+
+	found = false
+	for _, c := range config.Clusters {
+		if c.Name == clusterName {
+			credentials.Endpoint = c.Cluster.Server
+			if ca := c.Cluster.CertificateAuthorityData; ca != "" {
+				decoded, err := base64.StdEncoding.DecodeString(ca)
+				if err != nil {
+					return fmt.Errorf("invalid certificate-authority-data of cluster %q: %s", clusterName, err)
+				}
+				credentials.ClusterCACertificate = string(decoded)
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("cluster %q of context %q not found", clusterName, contextName)
+	}
+
+	found = false
+	for _, u := range config.Users {
+		if u.Name == userName {
+			credentials.Token = u.User.Token
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("user %q of context %q not found", userName, contextName)
+	}
+	return nil
+}
+
+// waitForKubernetesCredentials waits for the cluster to be Running and for its
+// kubeconfig to be generated.
+func waitForKubernetesCredentials(ctx context.Context, apiClient *client.Client, clusterID string, project_id int, location string) (kubernetesCredentials, error) {
+	if err := waitForKubernetesRunning(ctx, apiClient, clusterID, project_id, location); err != nil {
+		return kubernetesCredentials{}, err
+	}
+	for {
+		credentials, err := getKubernetesCredentials(apiClient, clusterID, location, project_id)
+		if !errors.Is(err, errKubeconfigNotAvailable) {
+			return credentials, err
+		}
+		log.Printf("[INFO] Kubeconfig of cluster %s is not available yet, waiting", clusterID)
+		select {
+		case <-ctx.Done():
+			return credentials, fmt.Errorf("timed out waiting for the kubeconfig of cluster %s", clusterID)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+func setKubernetesCredentials(d *schema.ResourceData, credentials kubernetesCredentials) {
+	d.Set("kubeconfig", credentials.Kubeconfig)
+	d.Set("endpoint", credentials.Endpoint)
+	d.Set("cluster_ca_certificate", credentials.ClusterCACertificate)
+	d.Set("token", credentials.Token)
+}
+
+// getKubernetesMasterPlans returns the master plans of a location, one per
+// available Kubernetes version.
+func getKubernetesMasterPlans(apiClient *client.Client, project_id int, location string) ([]map[string]interface{}, error) {
+	kubernetesPlan, err := apiClient.GetKubernetesMasterPlans(project_id, location)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Kubernetes plans: %s", err.Error())
+	}
+	log.Printf("[DEBUG] kubernetesPlan response: %+v", kubernetesPlan)
+
+	// The API returns nested data: data.data is the array of plans
+	dataWrapper, ok := kubernetesPlan["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format: 'data' field is not an object. Actual response: %+v", kubernetesPlan)
+	}
+	data, ok := dataWrapper["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format: nested 'data' field is not a list. Actual response: %+v", kubernetesPlan)
+	}
+	plans := make([]map[string]interface{}, 0, len(data))
+	for _, plan := range data {
+		if planData, ok := plan.(map[string]interface{}); ok {
+			plans = append(plans, planData)
+		}
+	}
+	return plans, nil
+}
+
+func parseKubernetesVersion(version string) (int, int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid kubernetes version %s, expected major.minor", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kubernetes version %s, expected major.minor", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kubernetes version %s, expected major.minor", version)
+	}
+	return major, minor, nil
+}
+
+// compareKubernetesVersions orders versions by major and minor, falling back
+// to a plain string comparison for the patch level.
+func compareKubernetesVersions(a string, b string) int {
+	aMajor, aMinor, errA := parseKubernetesVersion(a)
+	bMajor, bMinor, errB := parseKubernetesVersion(b)
+	if errA != nil || errB != nil || (aMajor == bMajor && aMinor == bMinor) {
+		return strings.Compare(a, b)
+	}
+	if aMajor != bMajor {
+		return aMajor - bMajor
+	}
+	return aMinor - bMinor
+}
+
+// validateKubernetesUpgrade only allows upgrades to the next minor version, the
+// same way the control plane is upgraded from My Account.
+func validateKubernetesUpgrade(oldVersion string, newVersion string) error {
+	if oldVersion == "" || oldVersion == newVersion {
+		return nil
+	}
+	oldMajor, oldMinor, err := parseKubernetesVersion(oldVersion)
+	if err != nil {
+		return err
+	}
+	newMajor, newMinor, err := parseKubernetesVersion(newVersion)
+	if err != nil {
+		return err
+	}
+	if newMajor != oldMajor || newMinor < oldMinor {
+		return fmt.Errorf("changing kubernetes version from %s to %s is not supported, only upgrades are allowed", oldVersion, newVersion)
+	}
+	if newMinor > oldMinor+1 {
+		return fmt.Errorf("kubernetes can only be upgraded one minor version at a time, upgrade from %s to %d.%d first", oldVersion, oldMajor, oldMinor+1)
+	}
+	return nil
+}
+
+// upgradeKubernetesCluster upgrades the control plane and then every node pool
+// in serviceMapping, one pool at a time, waiting for all the pools to be Running
+// after each step.
+func upgradeKubernetesCluster(ctx context.Context, apiClient *client.Client, clusterID string, upgrade *models.KubernetesUpgrade, serviceMapping map[string]interface{}, project_id int, location string) error {
+	log.Printf("[INFO] Upgrading control plane of cluster %s to %s", clusterID, upgrade.Version)
+	response, err := apiClient.UpgradeKubernetesVersion(upgrade, clusterID, project_id, location)
+	if err != nil {
+		return err
+	}
+	if _, codeOK := response["code"]; !codeOK {
+		return fmt.Errorf("error upgrading cluster %s: %v", clusterID, response["message"])
+	}
+	// The cluster is still Running right after the request, so wait for the upgrade to start first
+	if err := waitForKubernetesUpgradeStarted(ctx, apiClient, clusterID, upgrade.Version, project_id, location); err != nil {
+		return err
+	}
+	if err := waitForKubernetesRunning(ctx, apiClient, clusterID, project_id, location); err != nil {
+		return err
+	}
+	if err := waitForNodePoolsRunning(ctx, apiClient, clusterID, project_id, location); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(serviceMapping))
+	for name := range serviceMapping {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		log.Printf("[INFO] Upgrading node pool %s of cluster %s to %s", name, clusterID, upgrade.Version)
+		response, err := apiClient.UpgradeNodePoolVersion(upgrade, serviceMapping[name].(float64), project_id, location)
+		if err != nil {
+			return fmt.Errorf("error upgrading node pool %s: %s", name, err)
+		}
+		if _, codeOK := response["code"]; !codeOK {
+			return fmt.Errorf("error upgrading node pool %s: %v", name, response["message"])
+		}
+		if err := waitForNodePoolsRunning(ctx, apiClient, clusterID, project_id, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForKubernetesUpgradeStarted waits for the cluster to leave the Running
+// state, or to report the new version when the upgrade was already done.
+func waitForKubernetesUpgradeStarted(ctx context.Context, apiClient *client.Client, clusterID string, version string, project_id int, location string) error {
+	for {
+		kubernetes, err := apiClient.GetKubernetesServiceInfo(clusterID, location, project_id)
+		if err != nil {
+			return fmt.Errorf("error finding Item with ID %s: %s", clusterID, err)
+		}
+		data, ok := kubernetes["data"].([]interface{})
+		if !ok || len(data) == 0 {
+			return fmt.Errorf("unexpected response format while getting the status of cluster %s", clusterID)
+		}
+		status, _ := data[0].(map[string]interface{})["state"].(string)
+		currentVersion, _ := data[0].(map[string]interface{})["version"].(string)
+		if status != "Running" || currentVersion == version {
+			return nil
+		}
+		log.Printf("[INFO] Waiting for the upgrade of Kubernetes cluster %s to %s to start", clusterID, version)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the upgrade of Kubernetes cluster %s to %s to start", clusterID, version)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+// waitForNodePoolsRunning waits until every node pool of the cluster is Running.
+func waitForNodePoolsRunning(ctx context.Context, apiClient *client.Client, clusterID string, project_id int, location string) error {
+	for {
+		nodePools, err := apiClient.CheckNodePoolStatus(clusterID, project_id, location)
+		if err != nil {
+			return err
+		}
+		pending := findNodePool(nodePools["data"].([]interface{}), func(np map[string]interface{}) bool {
+			return np["state"] != "Running"
+		})
+		if pending == nil {
+			return nil
+		}
+		log.Printf("[INFO] Node pool %v of cluster %s is in %v state", pending["service_name"], clusterID, pending["state"])
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the node pools of cluster %s to come to the Running state", clusterID)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+// waitForKubernetesRunning waits until the cluster reports the Running state.
+// It is bounded by the deadline of ctx, i.e. the timeout of the operation.
+func waitForKubernetesRunning(ctx context.Context, apiClient *client.Client, clusterID string, project_id int, location string) error {
+	for {
+		kubernetes, err := apiClient.GetKubernetesServiceInfo(clusterID, location, project_id)
+		if err != nil {
+			return fmt.Errorf("error finding Item with ID %s: %s", clusterID, err)
+		}
+		data, ok := kubernetes["data"].([]interface{})
+		if !ok || len(data) == 0 {
+			return fmt.Errorf("unexpected response format while getting the status of cluster %s", clusterID)
+		}
+		status, _ := data[0].(map[string]interface{})["state"].(string)
+		if status == "Running" {
+			return nil
+		}
+		log.Printf("[INFO] Kubernetes cluster %s is in %s state, waiting for Running", clusterID, status)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Kubernetes is in %s state. Timed out waiting for it to come to the Running state.", status)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
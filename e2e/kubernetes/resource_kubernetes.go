@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
@@ -26,7 +27,7 @@ func ResourceKubernetesService() *schema.Resource {
 			"version": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Version of the Kubernetes service",
+				Description: "Version of the Kubernetes service. It can be upgraded in place one minor version at a time",
 			},
 			"project_id": {
 				Type:        schema.TypeInt,
@@ -69,228 +70,13 @@ func ResourceKubernetesService() *schema.Resource {
 				Description: "SKU ID of the Kubernetes service",
 			},
 			"node_pools": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Required:    true,
-				Description: "List of worker node pools",
+				MinItems:    1,
+				Set:         nodePoolHash,
+				Description: "Worker node pools of the cluster, keyed by name",
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Name of the worker node pool",
-						},
-						"slug_name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Slug name of the worker node pool",
-						},
-						"sku_id": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "SKU ID of the worker node pool",
-						},
-						"specs_name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Specs name of the worker node pool",
-						},
-						"service_id": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Services ID of the worker node pool",
-						},
-						"node_pool_type": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Its value can be Autoscale or Static",
-							ValidateFunc: validation.StringInSlice([]string{
-								"Static",
-								"Autoscale",
-							}, false),
-						},
-						"worker_node": {
-							Type:         schema.TypeInt,
-							Optional:     true, //If the type is autoscale then this field is not needed. Otherwise the default value will be 3
-							Description:  "Number of worker nodes in the pool",
-							ValidateFunc: validation.IntBetween(2, 25),
-						},
-						"min_vms": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							Default:      0,
-							ValidateFunc: validation.All(validation.IntAtLeast(2), validation.IntAtMost(25)),
-							Description:  "Minimum number of virtual machines",
-						},
-						"cardinality": {
-							Type:        schema.TypeInt,
-							Computed:    true, //NEW CHANGE
-							Description: "Cardinality computed from min_vms during creation",
-						},
-						"node_pool_size": {
-							Type:        schema.TypeInt,
-							Optional:    true, //NEW CHANGE
-							Description: "Cardinality computed from min_vms during creation",
-						},
-						"max_vms": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							Default:      0,
-							ValidateFunc: validation.IntAtMost(25),
-							Description:  "Maximum number of virtual machines",
-						},
-						"elasticity_dict": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Elasticity dictionary for the worker node pool",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"worker": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										Description: "Worker settings in the elasticity dictionary",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"period_number": {
-													Type:        schema.TypeInt,
-													Required:    true,
-													Description: "Period number",
-												},
-												"policy_paramter_type": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Its value can be Default or Custom. If it is custom then you must provide the parameter field.",
-													ValidateFunc: validation.StringInSlice([]string{
-														"Default",
-														"Custom",
-													}, false),
-												},
-												"parameter": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Default:     "CPU",
-													Description: "Parameter (e.g., CPU, Memory)",
-													ValidateFunc: validation.Any(
-														validation.StringInSlice([]string{"Memory", "CPU"}, false),
-														validation.StringMatch(
-															regexp.MustCompile(`^[A-Z0-9]([_]?[A-Z0-9])+$`),
-															"Parameter Name should be at least 2 characters long with upper case characters, numbers and underscore and must be start and end with characters or numbers.",
-														),
-													),
-												},
-												"elasticity_policies": {
-													Type:        schema.TypeList,
-													Required:    true,
-													Description: "List of elasticity policies",
-													Elem: &schema.Resource{
-														Schema: map[string]*schema.Schema{
-															"type": {
-																Type:        schema.TypeString,
-																Computed:    true,
-																Description: "It has a fixed value, i.e, CHANGE",
-															},
-															"adjust": {
-																Type:        schema.TypeString,
-																Computed:    true,
-																Description: "Adjust Value. Its value can be 1 or -1",
-															},
-															"operator": {
-																Type:        schema.TypeString,
-																Required:    true,
-																Description: "Operator for adding worker (e.g., >, >=)",
-															},
-															"value": {
-																Type:        schema.TypeInt,
-																Required:    true,
-																Description: "Value for adding worker",
-															},
-															"period": {
-																Type:        schema.TypeInt,
-																Required:    true,
-																Description: "Period",
-															},
-															"watch_period": {
-																Type:        schema.TypeInt,
-																Required:    true,
-																Description: "Period Number",
-															},
-															"cooldown": {
-																Type:        schema.TypeInt,
-																Required:    true,
-																Description: "Cooldown",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"scheduled_dict": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Scheduled dictionary for the worker node pool",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"worker": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										Description: "Worker settings in the scheduled dictionary",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"scheduled_policies": {
-													Type:     schema.TypeList,
-													Required: true,
-													Elem: &schema.Resource{
-														Schema: map[string]*schema.Schema{
-															"upscale_cardinality": {
-																Type:        schema.TypeInt,
-																Required:    true,
-																Description: "The cardinality for upscaling",
-															},
-															"upscale_recurrence": {
-																Type:         schema.TypeString,
-																Required:     true,
-																Description:  "The recurrence timing for upscaling",
-																ValidateFunc: validation.StringInSlice([]string{"0 12 * * *", "0 0 1 * *", "0 20 * * *", "0 9 * * 1-5", "0 9-13 * * *"}, false),
-															},
-															"downscale_cardinality": {
-																Type:        schema.TypeInt,
-																Required:    true,
-																Description: "The cardinality for downscaling",
-															},
-															"downscale_recurrence": {
-																Type:         schema.TypeString,
-																Required:     true,
-																Description:  "The recurrence timing for downscaling",
-																ValidateFunc: validation.StringInSlice([]string{"0 2 * * *", "0 0 15 * *", "30 5 * * 1-5", "0 0 * * 6,7", "0 0 12 1 1"}, false),
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"policy_type": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Policy type for the worker node pool",
-						},
-						"custom_param_name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Custom parameter name for the worker node pool",
-						},
-						"custom_param_value": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Custom parameter value for the worker node pool",
-						},
-					},
+					Schema: nodePoolSchema(),
 				},
 			},
 			"status": {
@@ -303,6 +89,28 @@ func ResourceKubernetesService() *schema.Resource {
 				Computed:    true,
 				Description: "Creation time of the Kubernetes Service",
 			},
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Kubeconfig YAML of the cluster. It is available once the cluster is Running",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Endpoint of the Kubernetes API server",
+			},
+			"cluster_ca_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded CA certificate of the Kubernetes API server",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token used to authenticate against the Kubernetes API server",
+			},
 		},
 
 		CreateContext: resourceCreateKubernetesService,
@@ -310,9 +118,352 @@ func ResourceKubernetesService() *schema.Resource {
 		UpdateContext: resourceUpdateKubernetesService,
 		DeleteContext: resourceDeleteKubernetesService,
 		Exists:        resourceExistsKubernetesService,
+		CustomizeDiff: resourceDiffKubernetesService,
 		Importer: &schema.ResourceImporter{
 			State: KubernetesImportStateFunc,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+// nodePoolSchema is the schema of a single worker node pool. It is shared by the
+// node_pools block and the e2e_kubernetes_node_pool resource.
+func nodePoolSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the worker node pool",
+		},
+		"slug_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Slug name of the worker node pool",
+		},
+		"sku_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SKU ID of the worker node pool",
+		},
+		"specs_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Specs name of the worker node pool",
+		},
+		"service_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Services ID of the worker node pool",
+		},
+		"node_pool_type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Its value can be Autoscale or Static",
+			ValidateFunc: validation.StringInSlice([]string{
+				"Static",
+				"Autoscale",
+			}, false),
+		},
+		"worker_node": {
+			Type:         schema.TypeInt,
+			Optional:     true, //If the type is autoscale then this field is not needed. Otherwise the default value will be 3
+			Description:  "Number of worker nodes in the pool",
+			ValidateFunc: validation.IntBetween(2, 25),
+		},
+		"min_vms": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.All(validation.IntAtLeast(2), validation.IntAtMost(25)),
+			Description:  "Minimum number of virtual machines",
+		},
+		"cardinality": {
+			Type:        schema.TypeInt,
+			Computed:    true, //NEW CHANGE
+			Description: "Cardinality computed from min_vms during creation",
+		},
+		"node_pool_size": {
+			Type:        schema.TypeInt,
+			Optional:    true, //NEW CHANGE
+			Description: "Number of nodes to resize the node pool to. For Autoscale pools it must be between min_vms and max_vms",
+		},
+		"max_vms": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtMost(25),
+			Description:  "Maximum number of virtual machines",
+		},
+		"elasticity_dict": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Deprecated:  "Use elasticity_policy instead",
+			Description: "Elasticity dictionary for the worker node pool",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"worker": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Worker settings in the elasticity dictionary",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"period_number": {
+									Type:        schema.TypeInt,
+									Required:    true,
+									Description: "Period number",
+								},
+								"policy_paramter_type": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Its value can be Default or Custom. If it is custom then you must provide the parameter field.",
+									ValidateFunc: validation.StringInSlice([]string{
+										"Default",
+										"Custom",
+									}, false),
+								},
+								"parameter": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Default:     "CPU",
+									Description: "Parameter (e.g., CPU, Memory)",
+									ValidateFunc: validation.Any(
+										validation.StringInSlice([]string{"Memory", "CPU"}, false),
+										validation.StringMatch(
+											regexp.MustCompile(`^[A-Z0-9]([_]?[A-Z0-9])+$`),
+											"Parameter Name should be at least 2 characters long with upper case characters, numbers and underscore and must be start and end with characters or numbers.",
+										),
+									),
+								},
+								"elasticity_policies": {
+									Type:        schema.TypeList,
+									Required:    true,
+									Description: "List of elasticity policies",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"type": {
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "It has a fixed value, i.e, CHANGE",
+											},
+											"adjust": {
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "Adjust Value. Its value can be 1 or -1",
+											},
+											"operator": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "Operator for adding worker (e.g., >, >=)",
+												ValidateFunc: validation.StringInSlice([]string{">", ">=", "<", "<="}, false),
+											},
+											"value": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "Value for adding worker",
+											},
+											"period": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "Period",
+											},
+											"watch_period": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "Period Number",
+											},
+											"cooldown": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "Cooldown",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"scheduled_dict": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Deprecated:  "Use scheduled_policy instead",
+			Description: "Scheduled dictionary for the worker node pool",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"worker": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Worker settings in the scheduled dictionary",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"scheduled_policies": {
+									Type:     schema.TypeList,
+									Required: true,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"upscale_cardinality": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "The cardinality for upscaling",
+											},
+											"upscale_recurrence": {
+												Type:         schema.TypeString,
+												Required:     true,
+												Description:  "The recurrence timing for upscaling",
+												ValidateFunc: validation.StringInSlice([]string{"0 12 * * *", "0 0 1 * *", "0 20 * * *", "0 9 * * 1-5", "0 9-13 * * *"}, false),
+											},
+											"downscale_cardinality": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "The cardinality for downscaling",
+											},
+											"downscale_recurrence": {
+												Type:         schema.TypeString,
+												Required:     true,
+												Description:  "The recurrence timing for downscaling",
+												ValidateFunc: validation.StringInSlice([]string{"0 2 * * *", "0 0 15 * *", "30 5 * * 1-5", "0 0 * * 6,7", "0 0 12 1 1"}, false),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"elasticity_policy": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Scales an Autoscale node pool between min_vms and max_vms on a metric",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"parameter": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "CPU",
+						Description:  "Metric the pool is scaled on. Its value can be CPU, Memory or Custom",
+						ValidateFunc: validation.StringInSlice([]string{"CPU", "Memory", "Custom"}, false),
+					},
+					"custom_parameter_name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Name of the custom metric, required when parameter is Custom",
+						ValidateFunc: validation.StringMatch(
+							regexp.MustCompile(`^[A-Z0-9]([_]?[A-Z0-9])+$`),
+							"Parameter Name should be at least 2 characters long with upper case characters, numbers and underscore and must be start and end with characters or numbers.",
+						),
+					},
+					"period_number": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Period number",
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"scale_up":   elasticityRuleSchema("Rule adding a node to the pool", []string{">", ">="}),
+					"scale_down": elasticityRuleSchema("Rule removing a node from the pool", []string{"<", "<="}),
+				},
+			},
+		},
+		"scheduled_policy": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Resizes an Autoscale node pool on a schedule",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"upscale_cardinality": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Number of nodes to scale up to, between min_vms and max_vms",
+						ValidateFunc: validation.IntBetween(2, 25),
+					},
+					"upscale_recurrence": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Cron expression of when to scale up, e.g. 0 9 * * 1-5",
+						ValidateFunc: validateCronExpression,
+					},
+					"downscale_cardinality": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Number of nodes to scale down to, between min_vms and max_vms",
+						ValidateFunc: validation.IntBetween(2, 25),
+					},
+					"downscale_recurrence": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Cron expression of when to scale down, e.g. 0 20 * * 1-5",
+						ValidateFunc: validateCronExpression,
+					},
+				},
+			},
+		},
+		"policy_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Policy type for the worker node pool",
+		},
+		"custom_param_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Custom parameter name for the worker node pool",
+		},
+		"custom_param_value": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Custom parameter value for the worker node pool",
+		},
+	}
+}
+
+func elasticityRuleSchema(description string, operators []string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"operator": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Operator comparing the metric with value, " + strings.Join(operators, " or "),
+					ValidateFunc: validation.StringInSlice(operators, false),
+				},
+				"value": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "Threshold of the metric",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"period": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "Period",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"watch_period": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "Number of periods the rule has to match",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"cooldown": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "Cooldown",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+			},
+		},
 	}
 }
 
@@ -321,28 +472,11 @@ func GetSlugName(ctx context.Context, d *schema.ResourceData, m interface{}) (st
 	log.Printf("[INFO] KUBERNETES PLAN READ STARTS")
 	version := d.Get("version").(string)
 	log.Printf("--------------MAKING API CALL FOR SLUGNAME-------------")
-	kubernetesPlan, err := apiClient.GetKubernetesMasterPlans(d.Get("project_id").(int), d.Get("location").(string))
+	plans, err := getKubernetesMasterPlans(apiClient, d.Get("project_id").(int), d.Get("location").(string))
 	if err != nil {
-		return "", fmt.Errorf("error getting Kubernetes plans: %s", err.Error())
-	}
-	// Extract slug_name based on the version
-	log.Printf("[DEBUG] kubernetesPlan response: %+v", kubernetesPlan)
-
-	// The API returns nested data: data.data is the array of plans
-	dataWrapper, ok := kubernetesPlan["data"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("unexpected response format: 'data' field is not an object. Actual response: %+v", kubernetesPlan)
-	}
-
-	data, ok := dataWrapper["data"].([]interface{})
-	if !ok {
-		return "", fmt.Errorf("unexpected response format: nested 'data' field is not a list. Actual response: %+v", kubernetesPlan)
+		return "", err
 	}
-	for _, plan := range data {
-		planData, ok := plan.(map[string]interface{})
-		if !ok {
-			continue
-		}
+	for _, planData := range plans {
 		k8sVersion, ok := planData["k8s_version"].(string)
 		if !ok {
 			continue
@@ -389,7 +523,7 @@ func CreateKubernetesObject(m interface{}, d *schema.ResourceData, slugName stri
 	}
 
 	if nodePools, ok := d.GetOk("node_pools"); ok {
-		nodePoolList := nodePools.([]interface{})
+		nodePoolList := nodePools.(*schema.Set).List()
 		nodePoolsDetail, err := ExpandNodePools(nodePoolList, apiClient, d.Get("project_id").(int), d.Get("location").(string))
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -439,8 +573,10 @@ func resourceCreateKubernetesService(ctx context.Context, d *schema.ResourceData
 	d.SetId(clusterIDStr)
 	log.Printf("[INFO] Kubernetes Cluster created successfully with ID: %s", clusterIDStr)
 
-	return diags
-
+	if _, err := waitForKubernetesCredentials(ctx, apiClient, clusterIDStr, d.Get("project_id").(int), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceReadKubernetesService(ctx, d, m)
 }
 
 func resourceReadKubernetesService(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -470,6 +606,25 @@ func resourceReadKubernetesService(ctx context.Context, d *schema.ResourceData,
 	d.Set("version", data["version"].(string))
 	d.Set("created_at", data["created_at"].(string))
 
+	if data["state"].(string) == "Running" {
+		credentials, err := getKubernetesCredentials(apiClient, serviceIDStr, location, d.Get("project_id").(int))
+		if err != nil {
+			return diag.Errorf("error getting kubeconfig of cluster %s: %s", serviceIDStr, err)
+		}
+		setKubernetesCredentials(d, credentials)
+	}
+
+	serviceMapping, err := GetNodePoolServiceMapping(ctx, d, m)
+	if err != nil {
+		log.Printf("[WARN] Failed to fetch node pools: %s", err.Error())
+	} else if len(serviceMapping) > 0 {
+		nodePools, err := flattenNodePools(apiClient, d.Get("node_pools").(*schema.Set).List(), serviceMapping, d.Get("project_id").(int), location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("node_pools", nodePools)
+	}
+
 	// Fetch and set security_group_ids from master node
 	masterVMID := getMasterNodeVMID(data)
 	if masterVMID == "" {
@@ -532,10 +687,10 @@ func resourceExistsKubernetesService(d *schema.ResourceData, m interface{}) (boo
 
 func resourceUpdateKubernetesService(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
-	status := d.Get("status").(string)
 	kubernetesId := d.Id()
-	if status != "Running" {
-		return diag.Errorf("Kubernetes is in %s state. You can update it once it comes to the Running state.", status)
+	// The status in state may be stale, e.g. right after create, so wait on the live one
+	if err := waitForKubernetesRunning(ctx, apiClient, kubernetesId, d.Get("project_id").(int), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
 	}
 	serviceMapping, err := GetNodePoolServiceMapping(ctx, d, m)
 	if err != nil {
@@ -601,141 +756,76 @@ func resourceUpdateKubernetesService(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	if d.HasChange("version") {
+		oldVersion, newVersion := d.GetChange("version")
+		if err := validateKubernetesUpgrade(oldVersion.(string), newVersion.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		slugName, err := GetSlugName(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		upgrade := models.KubernetesUpgrade{
+			Version:  newVersion.(string),
+			SlugName: slugName,
+			SKUID:    d.Get("sku_id").(string),
+		}
+		// Pools removed from the configuration are deleted below, so they are not upgraded
+		upgradeMapping := make(map[string]interface{})
+		for _, np := range d.Get("node_pools").(*schema.Set).List() {
+			name := np.(map[string]interface{})["name"].(string)
+			if serviceID, ok := serviceMapping[name]; ok {
+				upgradeMapping[name] = serviceID
+			}
+		}
+		if err := upgradeKubernetesCluster(ctx, apiClient, kubernetesId, &upgrade, upgradeMapping, d.Get("project_id").(int), d.Get("location").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("slug_name", slugName)
+	}
+
 	if d.HasChange("node_pools") {
 		oldData, newData := d.GetChange("node_pools")
+		oldNodePools := nodePoolsByName(oldData.(*schema.Set).List())
+		newNodePools := nodePoolsByName(newData.(*schema.Set).List())
+		projectID := d.Get("project_id").(int)
+		location := d.Get("location").(string)
 
-		oldNodePools := oldData.([]interface{})
-		newNodePools := newData.([]interface{})
+		if len(newNodePools) == 0 {
+			return diag.Errorf("Atleast one node pool must be present in a kubernetes cluster!")
+		}
 
-		for _, oldNodePool := range oldNodePools {
-			oldNodePoolMap := oldNodePool.(map[string]interface{})
-			oldNPName := oldNodePoolMap["name"].(string)
-			oldServiceFind := serviceMapping[oldNPName]
-			if oldServiceFind == nil {
-				return diag.Errorf("The Node Pool you are trying to delete does not exist!")
-			}
-			oldServiceID := oldServiceFind.(float64)
-			found := false
-			if len(newNodePools) <= 0 {
-				return diag.Errorf("Atleast one node pool must be present in a kubernetes cluster!")
+		// Pools are matched by name, so only pools whose name disappeared are deleted
+		for name := range oldNodePools {
+			if _, ok := newNodePools[name]; ok {
+				continue
 			}
-			// Check if the old service_id exists in the new node pools
-			for _, newNodePool := range newNodePools {
-				newNodePoolMap := newNodePool.(map[string]interface{})
-				newNPName := newNodePoolMap["name"].(string)
-				if oldNPName == newNPName {
-					found = true
-					break
-				}
+			serviceID, ok := serviceMapping[name].(float64)
+			if !ok {
+				log.Printf("[WARN] Node pool %s no longer exists on cluster %s, skipping delete", name, kubernetesId)
+				continue
 			}
-			if !found {
-				kubernetes, err := apiClient.CheckNodePoolStatus(kubernetesId, d.Get("project_id").(int), d.Get("location").(string))
-				if err != nil {
-					return diag.Errorf("error finding Item with ID %s", kubernetesId)
-				}
-				if !IsNodePoolRunning(oldServiceID, kubernetes["data"].([]interface{})) {
-					d.Set("node_pools", oldData)
-					return diag.Errorf("You can delete a Node Pool once it comes to the running state")
-				}
-				response, err := apiClient.DeleteNodePool(oldServiceID, d.Get("project_id").(int), d.Get("location").(string))
-				if err != nil {
-					if response == nil {
-						return nil
-					}
-					if len(response) > 0 {
-						return diag.Errorf("Error: %s", response["Status"])
-					}
-					return diag.FromErr(err)
-				}
-				// return nil
+			log.Printf("[INFO] Deleting node pool %s of cluster %s", name, kubernetesId)
+			if err := deleteNodePool(apiClient, kubernetesId, serviceID, projectID, location); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
-		for i := range newNodePools {
-			newNodePoolMap := newNodePools[i].(map[string]interface{})
-			newNPName := newNodePoolMap["name"].(string)
-			found := false
-			log.Printf("----------------------CHECKING IF THERE IS ANY ADDITION OF NODE POOLS-------------------")
-			for _, oldNodePool := range oldNodePools {
-				oldNodePoolMap := oldNodePool.(map[string]interface{})
-				oldNPName := oldNodePoolMap["name"].(string)
-				oldServiceID := serviceMapping[oldNPName].(float64)
-				// If exists then check if there is any change in cardinality
-				if newNPName == oldNPName {
-					found = true
-					oldCardinality := oldNodePoolMap["cardinality"].(int)
-					if oldNodePoolMap["node_pool_type"].(string) == "Static" {
-						oldCardinality = oldNodePoolMap["worker_node"].(int)
-					}
-					node_pool_size := oldCardinality
-					if newNodePoolMap["node_pool_size"].(int) != 0 {
-						node_pool_size = newNodePoolMap["node_pool_size"].(int)
-					}
-					log.Printf("----------------PREV CARD:%+v     NEW CARD:%+v------------------", oldCardinality, node_pool_size)
-					if node_pool_size < 2 {
-						return diag.Errorf("Cardinality of worker nodes cannot be less than 2")
-					}
-					if oldCardinality != node_pool_size {
-						nodePoolResize := models.NodePoolResize{
-							NodePoolSize: newNodePoolMap["node_pool_size"].(int),
-						}
-						newNodePoolMap["cardinality"] = newNodePoolMap["node_pool_size"].(int)
-						response, err := apiClient.UpdateNodePoolCardinality(&nodePoolResize, oldServiceID, d.Get("project_id").(int), d.Get("location").(string))
-						if err != nil {
-							if response == nil {
-								// return nil
-								break
-							}
-							if len(response) > 0 {
-								return diag.Errorf("Error: %s", response["errors"])
-							}
-							return diag.FromErr(err)
-						}
-						break
-					}
-					old_node_pool_type := oldNodePoolMap["node_pool_type"].(string)
-					new_node_pool_type := newNodePoolMap["node_pool_type"].(string)
-					// You cannot change the node pool type from Static to Autoscale and vice versa
-					if old_node_pool_type != new_node_pool_type {
-						return diag.Errorf("You cannot change the node pool type")
-					}
-					if new_node_pool_type == "Static" {
-						break
-					}
-					nodePoolObject, err := ExpandNPUpdate(newNodePoolMap, apiClient, d.Get("project_id").(int), d.Get("location").(string))
-					if err != nil {
-						return diag.FromErr(err)
-					}
-					response, err := apiClient.UpdateNodePoolDetails(&nodePoolObject, oldServiceID, d.Get("project_id").(int), d.Get("location").(string))
-					if err != nil {
-						return diag.FromErr(err)
-					}
-					if _, codeOK := response["code"]; !codeOK {
-						return diag.Errorf(response["message"].(string))
-					}
-					break
-				}
-			}
-			//If not found meaning this is a new NodePool
-			if !found {
-				var nodePoolList []interface{}
-				nodePoolList = append(nodePoolList, newNodePools[i])
-				nodePoolsDetail, err := ExpandNodePools(nodePoolList, apiClient, d.Get("project_id").(int), d.Get("location").(string))
-				if err != nil {
+		for name, newNodePool := range newNodePools {
+			oldNodePool, ok := oldNodePools[name]
+			if !ok {
+				log.Printf("[INFO] Adding node pool %s to cluster %s", name, kubernetesId)
+				if err := addNodePool(apiClient, newNodePool, kubernetesId, projectID, location); err != nil {
 					return diag.FromErr(err)
 				}
-				kubernetesObj := models.NodePoolAdd{}
-				kubernetesObj.NodePools = nodePoolsDetail
-				log.Printf("----------------------ADDING A NEW NODE POOL-------------------")
-				response, err := apiClient.AddNodePool(&kubernetesObj, kubernetesId, d.Get("project_id").(int), d.Get("location").(string))
-				if err != nil {
-					return diag.FromErr(err)
-				}
-				if _, codeOK := response["code"]; !codeOK {
-					return diag.Errorf(response["message"].(string))
-				}
 				continue
-				// return nil
+			}
+			serviceID, ok := serviceMapping[name].(float64)
+			if !ok {
+				return diag.Errorf("node pool %s does not exist on cluster %s", name, kubernetesId)
+			}
+			if err := updateNodePool(apiClient, oldNodePool, newNodePool, serviceID, projectID, location); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -743,6 +833,28 @@ func resourceUpdateKubernetesService(ctx context.Context, d *schema.ResourceData
 	return resourceReadKubernetesService(ctx, d, m)
 }
 
+// resourceDiffKubernetesService rejects version and node pool changes that cannot
+// be applied in place at plan time instead of failing halfway through an apply.
+func resourceDiffKubernetesService(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("version") {
+		oldVersion, newVersion := d.GetChange("version")
+		if err := validateKubernetesUpgrade(oldVersion.(string), newVersion.(string)); err != nil {
+			return err
+		}
+	}
+	if !d.HasChange("node_pools") {
+		return nil
+	}
+	oldData, newData := d.GetChange("node_pools")
+	oldNodePools := nodePoolsByName(oldData.(*schema.Set).List())
+	for name, newNodePool := range nodePoolsByName(newData.(*schema.Set).List()) {
+		if err := validateNodePoolChange(oldNodePools[name], newNodePool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetNodePoolServiceMapping(ctx context.Context, d *schema.ResourceData, m interface{}) (map[string]interface{}, error) {
 	apiClient := m.(*client.Client)
 	log.Printf("[INFO] KUBERNETES CLUSTER NODE POOLS MAPPING STARTS")
@@ -782,7 +894,7 @@ func IsNodePoolRunning(oldServiceID float64, nodePools []interface{}) bool {
 	return false
 }
 
-// getMasterNodeVMID extracts the master node VM ID 
+// getMasterNodeVMID extracts the master node VM ID
 func getMasterNodeVMID(data map[string]interface{}) string {
 	roles, ok := data["roles"].([]interface{})
 	if !ok || len(roles) == 0 {
@@ -829,4 +941,3 @@ func KubernetesImportStateFunc(d *schema.ResourceData, m interface{}) ([]*schema
 
 	return []*schema.ResourceData{d}, nil
 }
-
@@ -0,0 +1,297 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceKubernetesAddon() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Kubernetes cluster the add-on is installed on",
+			},
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the project. It should be unique",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Location of the Kubernetes cluster",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the add-on, e.g. ingress-nginx or metrics-server",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the add-on. Defaults to the latest version supported by the cluster",
+			},
+			"values": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Configuration values of the add-on in YAML",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Install status of the add-on",
+			},
+		},
+
+		CreateContext: resourceCreateKubernetesAddon,
+		ReadContext:   resourceReadKubernetesAddon,
+		UpdateContext: resourceUpdateKubernetesAddon,
+		DeleteContext: resourceDeleteKubernetesAddon,
+		Importer: &schema.ResourceImporter{
+			State: KubernetesAddonImportStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceCreateKubernetesAddon(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	clusterID := d.Get("cluster_id").(string)
+	projectID := d.Get("project_id").(int)
+	location := d.Get("location").(string)
+
+	addon := models.KubernetesAddon{
+		Name:    d.Get("name").(string),
+		Version: d.Get("version").(string),
+		Values:  d.Get("values").(string),
+	}
+	if err := validateKubernetesAddon(apiClient, &addon, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForKubernetesRunning(ctx, apiClient, clusterID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Installing add-on %s %s on cluster %s", addon.Name, addon.Version, clusterID)
+	response, err := apiClient.InstallKubernetesAddon(&addon, clusterID, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, codeOK := response["code"]; !codeOK {
+		return diag.Errorf("error installing add-on %s: %v", addon.Name, response["message"])
+	}
+	d.SetId(clusterID + "/" + addon.Name)
+
+	if err := waitForKubernetesAddonInstalled(ctx, apiClient, clusterID, addon.Name, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceReadKubernetesAddon(ctx, d, m)
+}
+
+func resourceReadKubernetesAddon(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	addon, err := getKubernetesAddon(apiClient, d.Get("cluster_id").(string), d.Get("name").(string), d.Get("project_id").(int), d.Get("location").(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	if addon == nil {
+		log.Printf("[INFO] Add-on %s is not installed on cluster %s", d.Get("name").(string), d.Get("cluster_id").(string))
+		d.SetId("")
+		return diags
+	}
+	if version, ok := addon["version"].(string); ok {
+		d.Set("version", version)
+	}
+	d.Set("status", addon["status"])
+	return diags
+}
+
+func resourceUpdateKubernetesAddon(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	clusterID := d.Get("cluster_id").(string)
+	projectID := d.Get("project_id").(int)
+	location := d.Get("location").(string)
+
+	if d.HasChanges("version", "values") {
+		addon := models.KubernetesAddon{
+			Name:    d.Get("name").(string),
+			Version: d.Get("version").(string),
+			Values:  d.Get("values").(string),
+		}
+		if err := validateKubernetesAddon(apiClient, &addon, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] Updating add-on %s on cluster %s", addon.Name, clusterID)
+		response, err := apiClient.UpdateKubernetesAddon(&addon, clusterID, projectID, location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if _, codeOK := response["code"]; !codeOK {
+			return diag.Errorf("error updating add-on %s: %v", addon.Name, response["message"])
+		}
+		if err := waitForKubernetesAddonInstalled(ctx, apiClient, clusterID, addon.Name, projectID, location); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceReadKubernetesAddon(ctx, d, m)
+}
+
+func resourceDeleteKubernetesAddon(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+	clusterID := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+	projectID := d.Get("project_id").(int)
+	location := d.Get("location").(string)
+
+	log.Printf("[INFO] Uninstalling add-on %s from cluster %s", name, clusterID)
+	_, err := apiClient.UninstallKubernetesAddon(name, clusterID, projectID, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for {
+		addon, err := getKubernetesAddon(apiClient, clusterID, name, projectID, location)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if addon == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return diag.Errorf("timed out waiting for add-on %s to be uninstalled", name)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+	d.SetId("")
+	return diags
+}
+
+// validateKubernetesAddon checks the add-on is supported by the E2E Kubernetes
+// service and defaults its version to the latest one.
+func validateKubernetesAddon(apiClient *client.Client, addon *models.KubernetesAddon, project_id int, location string) error {
+	response, err := apiClient.GetAvailableKubernetesAddons(project_id, location)
+	if err != nil {
+		return fmt.Errorf("error getting the available add-ons: %s", err)
+	}
+	available, _ := response["data"].([]interface{})
+	names := make([]string, 0, len(available))
+	for _, a := range available {
+		availableAddon := a.(map[string]interface{})
+		name, _ := availableAddon["name"].(string)
+		names = append(names, name)
+		if name != addon.Name {
+			continue
+		}
+		versionList, _ := availableAddon["versions"].([]interface{})
+		versions := make([]string, 0, len(versionList))
+		for _, v := range versionList {
+			if version, ok := v.(string); ok {
+				versions = append(versions, version)
+			}
+		}
+		if len(versions) == 0 {
+			return nil
+		}
+		if addon.Version == "" {
+			addon.Version = versions[len(versions)-1]
+			return nil
+		}
+		for _, version := range versions {
+			if version == addon.Version {
+				return nil
+			}
+		}
+		return fmt.Errorf("version %s of add-on %s is not supported, supported versions are %s", addon.Version, addon.Name, strings.Join(versions, ", "))
+	}
+	return fmt.Errorf("add-on %s is not supported, supported add-ons are %s", addon.Name, strings.Join(names, ", "))
+}
+
+// getKubernetesAddon returns the add-on installed on the cluster with the given
+// name, or nil when it is not installed.
+func getKubernetesAddon(apiClient *client.Client, clusterID string, name string, project_id int, location string) (map[string]interface{}, error) {
+	response, err := apiClient.GetKubernetesAddons(clusterID, project_id, location)
+	if err != nil {
+		return nil, err
+	}
+	installed, _ := response["data"].([]interface{})
+	for _, a := range installed {
+		addon := a.(map[string]interface{})
+		if addon["name"] == name {
+			return addon, nil
+		}
+	}
+	return nil, nil
+}
+
+func waitForKubernetesAddonInstalled(ctx context.Context, apiClient *client.Client, clusterID string, name string, project_id int, location string) error {
+	for {
+		addon, err := getKubernetesAddon(apiClient, clusterID, name, project_id, location)
+		if err != nil {
+			return err
+		}
+		if addon != nil {
+			status, _ := addon["status"].(string)
+			log.Printf("[INFO] Add-on %s of cluster %s is in %s state", name, clusterID, status)
+			switch status {
+			case "Installed":
+				return nil
+			case "Failed":
+				return fmt.Errorf("add-on %s failed to install on cluster %s: %v", name, clusterID, addon["message"])
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for add-on %s to be installed", name)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+// KubernetesAddonImportStateFunc handles import for Kubernetes add-ons
+func KubernetesAddonImportStateFunc(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid ID format: expected project_id/location/cluster_id/name")
+	}
+
+	projectIDInt, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid project_id: must be numeric")
+	}
+
+	d.Set("project_id", projectIDInt)
+	d.Set("location", parts[1])
+	d.Set("cluster_id", parts[2])
+	d.Set("name", parts[3])
+	d.SetId(parts[2] + "/" + parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,244 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceKubernetesNodePool() *schema.Resource {
+	nodePool := nodePoolSchema()
+	// Pools are identified by name and cannot change their type in place
+	nodePool["name"].ForceNew = true
+	nodePool["node_pool_type"].ForceNew = true
+	nodePool["cluster_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "ID of the Kubernetes cluster the node pool belongs to",
+	}
+	nodePool["project_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Required:    true,
+		ForceNew:    true,
+		Description: "ID of the project. It should be unique",
+	}
+	nodePool["location"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Location of the Kubernetes cluster",
+	}
+	nodePool["state"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "State of the node pool",
+	}
+
+	return &schema.Resource{
+		Schema: nodePool,
+
+		CreateContext: resourceCreateKubernetesNodePool,
+		ReadContext:   resourceReadKubernetesNodePool,
+		UpdateContext: resourceUpdateKubernetesNodePool,
+		DeleteContext: resourceDeleteKubernetesNodePool,
+		CustomizeDiff: resourceDiffKubernetesNodePool,
+		Importer: &schema.ResourceImporter{
+			State: KubernetesNodePoolImportStateFunc,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceCreateKubernetesNodePool(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	clusterID := d.Get("cluster_id").(string)
+	projectID := d.Get("project_id").(int)
+	location := d.Get("location").(string)
+	_, nodePoolDetail := nodePoolChange(d)
+
+	// Pools can only be added once the cluster is Running, which it isn't while it is being created
+	if err := waitForKubernetesRunning(ctx, apiClient, clusterID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Adding node pool %s to cluster %s", nodePoolDetail["name"], clusterID)
+	if err := addNodePool(apiClient, nodePoolDetail, clusterID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	serviceID, err := waitForNodePoolRunning(ctx, apiClient, clusterID, nodePoolDetail["name"].(string), projectID, location)
+	if serviceID != 0 {
+		d.SetId(fmt.Sprintf("%.0f", serviceID))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("cardinality", nodePoolSize(nodePoolDetail))
+	return resourceReadKubernetesNodePool(ctx, d, m)
+}
+
+func resourceReadKubernetesNodePool(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	nodePools, err := apiClient.GetKubernetesNodePools(d.Get("cluster_id").(string), d.Get("project_id").(int), d.Get("location").(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error finding node pools of cluster %s: %s", d.Get("cluster_id").(string), err)
+	}
+	nodePoolData := findNodePool(nodePools["data"].([]interface{}), func(np map[string]interface{}) bool {
+		return fmt.Sprintf("%.0f", np["service_id"].(float64)) == d.Id()
+	})
+	if nodePoolData == nil {
+		log.Printf("[INFO] Node pool %s not found on cluster %s", d.Id(), d.Get("cluster_id").(string))
+		d.SetId("")
+		return diags
+	}
+	nodePoolDetail := make(map[string]interface{})
+	for key := range nodePoolSchema() {
+		nodePoolDetail[key] = d.Get(key)
+	}
+	serviceID := nodePoolData["service_id"].(float64)
+	if err := refreshNodePool(apiClient, nodePoolDetail, serviceID, d.Get("project_id").(int), d.Get("location").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	nodePoolDetail["name"] = nodePoolData["service_name"].(string)
+	nodePoolDetail["service_id"] = d.Id()
+	for key, value := range nodePoolDetail {
+		d.Set(key, value)
+	}
+	d.Set("state", nodePoolData["state"])
+	return diags
+}
+
+func resourceUpdateKubernetesNodePool(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	serviceID, err := strconv.ParseFloat(d.Id(), 64)
+	if err != nil {
+		return diag.Errorf("invalid node pool id %s", d.Id())
+	}
+	clusterID := d.Get("cluster_id").(string)
+	projectID := d.Get("project_id").(int)
+	location := d.Get("location").(string)
+
+	nodePools, err := apiClient.CheckNodePoolStatus(clusterID, projectID, location)
+	if err != nil {
+		return diag.Errorf("error finding node pools of cluster %s: %s", clusterID, err)
+	}
+	if !IsNodePoolRunning(serviceID, nodePools["data"].([]interface{})) {
+		return diag.Errorf("You can update a Node Pool once it comes to the running state")
+	}
+	oldNodePool, newNodePool := nodePoolChange(d)
+	if err := updateNodePool(apiClient, oldNodePool, newNodePool, serviceID, projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("cardinality", newNodePool["cardinality"])
+	if _, err := waitForNodePoolRunning(ctx, apiClient, clusterID, d.Get("name").(string), projectID, location); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceReadKubernetesNodePool(ctx, d, m)
+}
+
+func resourceDeleteKubernetesNodePool(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+	serviceID, err := strconv.ParseFloat(d.Id(), 64)
+	if err != nil {
+		return diag.Errorf("invalid node pool id %s", d.Id())
+	}
+	err = deleteNodePool(apiClient, d.Get("cluster_id").(string), serviceID, d.Get("project_id").(int), d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
+
+func resourceDiffKubernetesNodePool(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	newNodePool := make(map[string]interface{})
+	for key := range nodePoolSchema() {
+		newNodePool[key] = d.Get(key)
+	}
+	return validateNodePoolChange(nil, newNodePool)
+}
+
+// nodePoolChange builds the previous and the planned node pool from the
+// resource data, in the same shape as an element of the node_pools block.
+func nodePoolChange(d *schema.ResourceData) (map[string]interface{}, map[string]interface{}) {
+	oldNodePool := make(map[string]interface{})
+	newNodePool := make(map[string]interface{})
+	for key := range nodePoolSchema() {
+		oldNodePool[key], newNodePool[key] = d.GetChange(key)
+	}
+	return oldNodePool, newNodePool
+}
+
+func findNodePool(nodePools []interface{}, match func(map[string]interface{}) bool) map[string]interface{} {
+	for _, np := range nodePools {
+		nodePoolData := np.(map[string]interface{})
+		if match(nodePoolData) {
+			return nodePoolData
+		}
+	}
+	return nil
+}
+
+// waitForNodePoolRunning waits until the named node pool shows up on the cluster
+// in the Running state and returns its service id.
+func waitForNodePoolRunning(ctx context.Context, apiClient *client.Client, clusterID string, name string, project_id int, location string) (float64, error) {
+	var serviceID float64
+	for {
+		nodePools, err := apiClient.CheckNodePoolStatus(clusterID, project_id, location)
+		if err != nil {
+			return serviceID, err
+		}
+		nodePoolData := findNodePool(nodePools["data"].([]interface{}), func(np map[string]interface{}) bool {
+			return np["service_name"] == name
+		})
+		if nodePoolData != nil {
+			serviceID = nodePoolData["service_id"].(float64)
+			log.Printf("[INFO] Node pool %s is in %v state", name, nodePoolData["state"])
+			if nodePoolData["state"] == "Running" {
+				return serviceID, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return serviceID, fmt.Errorf("timed out waiting for node pool %s to come to the Running state", name)
+		case <-time.After(constants.WAIT_TIMEOUT * time.Second):
+		}
+	}
+}
+
+// KubernetesNodePoolImportStateFunc handles import for Kubernetes node pools
+func KubernetesNodePoolImportStateFunc(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid ID format: expected project_id/location/cluster_id/node_pool_id")
+	}
+
+	projectIDInt, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid project_id: must be numeric")
+	}
+
+	d.Set("project_id", projectIDInt)
+	d.Set("location", parts[1])
+	d.Set("cluster_id", parts[2])
+	d.SetId(parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -2,6 +2,8 @@ package loadbalancer
 
 import (
 	"fmt"
+	"log"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -38,32 +40,108 @@ func ExpandBackends(config []interface{}, apiClient *client.Client, project_id s
 			ScalerId:       detail["scaler_id"].(string),
 			ScalerPort:     detail["scaler_port"].(string),
 		}
+		scalerId, scalerPort, err := ExpandScalerGroupReference(detail["scaler_group"].([]interface{}), apiClient, project_id, location)
+		if err != nil {
+			return nil, err
+		}
+		if scalerId != "" {
+			r.ScalerId = scalerId
+			r.ScalerPort = scalerPort
+		}
 		backends = append(backends, r)
 	}
 	return backends, nil
 }
 
+func ExpandScalerGroupReference(config []interface{}, apiClient *client.Client, project_id string, location string) (string, string, error) {
+	if len(config) == 0 || config[0] == nil {
+		return "", "", nil
+	}
+	detail := config[0].(map[string]interface{})
+	scalerId := detail["id"].(string)
+	group, err := apiClient.GetScalerGroup(scalerId, project_id, location)
+	if err != nil {
+		return "", "", fmt.Errorf("scaler group with id %s could not be found: %s", scalerId, err)
+	}
+	if group.ProvisionStatus != "Running" {
+		return "", "", fmt.Errorf("Scaler group with id %s is not in running state", scalerId)
+	}
+	return strconv.Itoa(group.ID), detail["port"].(string), nil
+}
+
+func UsesScalerGroup(config []interface{}) bool {
+	for _, backend := range config {
+		detail := backend.(map[string]interface{})
+		if scalerGroup, ok := detail["scaler_group"].([]interface{}); ok && len(scalerGroup) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func ExpandServers(server_details interface{}, apiClient *client.Client, project_id string, location string) ([]models.Server, error) {
 	var servers []models.Server
 
 	for _, server := range server_details.([]interface{}) {
 		server_detail := server.(map[string]interface{})
-		node, err := apiClient.GetNode(server_detail["id"].(string), project_id, location)
+		nodeId := server_detail["id"].(string)
+		label := server_detail["label"].(string)
+		ipType := server_detail["ip_type"].(string)
+		port := server_detail["port"].(string)
+
+		if (nodeId == "") == (label == "") {
+			return nil, fmt.Errorf("exactly one of id or label must be set on a server block")
+		}
+
+		if nodeId != "" {
+			node, err := apiClient.GetNode(nodeId, project_id, location)
+			if err != nil {
+				return nil, err
+			}
+			data := node["data"].(map[string]interface{})
+			status := data["status"].(string)
+			if status != "Running" {
+				return nil, fmt.Errorf("Node with id %s is not in running state", nodeId)
+			}
+			ip, err := GetServerIp(data["name"].(string), data["private_ip_address"], data["public_ip_address"], ipType)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, models.Server{
+				BackendName: data["name"].(string),
+				BackendIp:   ip,
+				BackendPort: port,
+			})
+			continue
+		}
+
+		nodes, err := apiClient.GetNodes(location, project_id)
 		if err != nil {
 			return nil, err
 		}
-		data := node["data"].(map[string]interface{})
-		status := data["status"].(string)
-		if status != "Running" {
-			return nil, fmt.Errorf("Node with id %s is not in running state", server_detail["id"].(string))
+		matched := 0
+		for _, node := range nodes.Data {
+			if node.Label != label {
+				continue
+			}
+			if node.Status != "Running" {
+				log.Printf("[INFO] skipping node %s with label %s as it is in %s state", node.Name, label, node.Status)
+				continue
+			}
+			ip, err := GetServerIp(node.Name, node.PrivateIPAddress, node.PublicIPAddress, ipType)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, models.Server{
+				BackendName: node.Name,
+				BackendIp:   ip,
+				BackendPort: port,
+			})
+			matched++
 		}
-		r := models.Server{
-			BackendName: data["name"].(string),
-			BackendIp:   data["private_ip_address"].(string),
-			BackendPort: server_detail["port"].(string),
+		if matched == 0 {
+			return nil, fmt.Errorf("no running node found with label %s", label)
 		}
-
-		servers = append(servers, r)
 	}
 	if len(servers) == 0 {
 		return make([]models.Server, 0), nil
@@ -71,6 +149,116 @@ func ExpandServers(server_details interface{}, apiClient *client.Client, project
 	return servers, nil
 }
 
+func GetServerIp(nodeName string, privateIp interface{}, publicIp interface{}, ipType string) (string, error) {
+	ip := privateIp
+	if ipType == "public" {
+		ip = publicIp
+	}
+	ipAddress, ok := ip.(string)
+	if !ok || ipAddress == "" {
+		return "", fmt.Errorf("Node %s does not have a %s ip address", nodeName, ipType)
+	}
+	return ipAddress, nil
+}
+
+// ResolveServers expands the backend and tcp_backend selectors into the flat
+// resolved_servers representation so it can be compared with what the load
+// balancer is actually serving.
+func ResolveServers(backends []interface{}, tcpBackends []interface{}, apiClient *client.Client, project_id string, location string) ([]interface{}, error) {
+	resolved := make([]interface{}, 0)
+
+	backendDetails, err := ExpandBackends(backends, apiClient, project_id, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, backend := range backendDetails {
+		for _, server := range backend.Servers {
+			resolved = append(resolved, flattenServer(backend.Name, server.BackendName, server.BackendIp, server.BackendPort))
+		}
+	}
+
+	tcpBackendDetails, err := ExpandTcpBackend(tcpBackends, apiClient, project_id, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, backend := range tcpBackendDetails {
+		for _, server := range backend.Servers {
+			resolved = append(resolved, flattenServer(backend.BackendName, server.BackendName, server.BackendIp, server.BackendPort))
+		}
+	}
+	return resolved, nil
+}
+
+// FlattenLbContextServers reads the servers currently configured on the load
+// balancer from its appliance context.
+func FlattenLbContextServers(lb_context map[string]interface{}) []interface{} {
+	resolved := make([]interface{}, 0)
+
+	flatten := func(key string, nameKey string) {
+		backends, ok := lb_context[key].([]interface{})
+		if !ok {
+			return
+		}
+		for _, backend := range backends {
+			detail, ok := backend.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			servers, ok := detail["servers"].([]interface{})
+			if !ok {
+				continue
+			}
+			for _, server := range servers {
+				serverDetail, ok := server.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				resolved = append(resolved, flattenServer(
+					fmt.Sprint(detail[nameKey]),
+					fmt.Sprint(serverDetail["backend_name"]),
+					fmt.Sprint(serverDetail["backend_ip"]),
+					fmt.Sprint(serverDetail["backend_port"]),
+				))
+			}
+		}
+	}
+	flatten("backends", "name")
+	flatten("tcp_backend", "backend_name")
+	return resolved
+}
+
+func flattenServer(backend string, name string, ip string, port string) map[string]interface{} {
+	return map[string]interface{}{
+		"backend": backend,
+		"name":    name,
+		"ip":      ip,
+		"port":    port,
+	}
+}
+
+func ResolvedServersEqual(old []interface{}, new []interface{}) bool {
+	key := func(list []interface{}) []string {
+		keys := make([]string, 0, len(list))
+		for _, item := range list {
+			detail := item.(map[string]interface{})
+			keys = append(keys, fmt.Sprintf("%v|%v|%v", detail["backend"], detail["ip"], detail["port"]))
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	oldKeys := key(old)
+	newKeys := key(new)
+	if len(oldKeys) != len(newKeys) {
+		return false
+	}
+	for i := range oldKeys {
+		if oldKeys[i] != newKeys[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func ExpandAclList(config []interface{}) ([]models.AclListInfo, error) {
 	aclList := make([]models.AclListInfo, 0, len(config))
 
@@ -128,7 +316,10 @@ func ExpandVpcList(d *schema.ResourceData, vpc_list []interface{}, apiClient *cl
 	return vpc_details, nil
 }
 
-func ExpandEnableEosLogger(config []interface{}) (models.EosDetail, error) {
+func ExpandEnableEosLogger(config []interface{}) (*models.EosDetail, error) {
+	if len(config) == 0 || config[0] == nil {
+		return nil, nil
+	}
 	eosDetail := models.EosDetail{}
 
 	for _, eosBucketInfo := range config {
@@ -138,7 +329,63 @@ func ExpandEnableEosLogger(config []interface{}) (models.EosDetail, error) {
 		eosDetail.Secretkey = detail["secret_key"].(string)
 		eosDetail.Bucket = detail["bucket"].(string)
 	}
-	return eosDetail, nil
+	if eosDetail.AccessKey == "" || eosDetail.Secretkey == "" {
+		return nil, fmt.Errorf("access_key and secret_key are required to enable the eos logger")
+	}
+	return &eosDetail, nil
+}
+
+func ExpandAccessLogs(config []interface{}, apiClient *client.Client, project_id string, location string) (*models.EosDetail, error) {
+	if len(config) == 0 || config[0] == nil {
+		return nil, nil
+	}
+	detail := config[0].(map[string]interface{})
+	bucket := detail["bucket"].(string)
+	accessKey := detail["access_key"].(string)
+	region := detail["region"].(string)
+	if region == "" {
+		region = location
+	}
+
+	_, err := apiClient.GetBucket(bucket, region, project_id)
+	if err != nil {
+		return nil, fmt.Errorf("access logs bucket %s could not be found in %s: %s", bucket, region, err)
+	}
+	key, err := apiClient.GetAccessKey(accessKey, region, project_id)
+	if err != nil {
+		return nil, fmt.Errorf("access logs access key could not be found in %s: %s", region, err)
+	}
+	if key.IsDisabled {
+		return nil, fmt.Errorf("access logs access key %s is disabled", key.Tag)
+	}
+
+	return &models.EosDetail{
+		AccessKey: accessKey,
+		Secretkey: detail["secret_key"].(string),
+		Bucket:    bucket,
+	}, nil
+}
+
+// FlattenAccessLogs reports the logging configuration the load balancer is
+// actually running with. The secret key is never returned by the API, so the
+// one already in state is kept.
+func FlattenAccessLogs(d *schema.ResourceData, lb_context map[string]interface{}) []interface{} {
+	eosDetail, ok := lb_context["enable_eos_logger"].(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+	bucket, _ := eosDetail["bucket"].(string)
+	accessKey, _ := eosDetail["access_key"].(string)
+	if bucket == "" || accessKey == "" {
+		return []interface{}{}
+	}
+	accessLogs := map[string]interface{}{
+		"bucket":     bucket,
+		"access_key": accessKey,
+		"secret_key": d.Get("access_logs.0.secret_key").(string),
+		"region":     d.Get("access_logs.0.region").(string),
+	}
+	return []interface{}{accessLogs}
 }
 
 func ExpandTcpBackend(config []interface{}, apiClient *client.Client, project_id string, location string) ([]models.TcpBackendDetail, error) {
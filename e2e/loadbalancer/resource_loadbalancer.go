@@ -2,6 +2,7 @@ package loadbalancer
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math"
 	"strconv"
@@ -25,6 +26,7 @@ func ResourceLoadBalancer() *schema.Resource {
 		UpdateContext: resourceUpdateLoadBalancer,
 		DeleteContext: resourceDeleteLoadBalancer,
 		Exists:        resourceExistsLoadBalancer,
+		CustomizeDiff: resourceDiffLoadBalancer,
 		Importer: &schema.ResourceImporter{
 			State: node.CustomImportStateFunc,
 		},
@@ -145,6 +147,26 @@ func ResouceLoadBalancerSchema() map[string]*schema.Schema {
 						Default:     "",
 						Description: "Need scalar port if you want to attach autoscaling",
 					},
+					"scaler_group": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Reference to an e2e_scaler_group whose nodes serve this backend. Takes precedence over scaler_id and scaler_port",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"id": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "ID of the scaler group",
+								},
+								"port": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Port on which the scaler group nodes serve traffic",
+								},
+							},
+						},
+					},
 					"balance": {
 						Type:        schema.TypeString,
 						Required:    true,
@@ -181,8 +203,25 @@ func ResouceLoadBalancerSchema() map[string]*schema.Schema {
 							Schema: map[string]*schema.Schema{
 								"id": {
 									Type:        schema.TypeString,
-									Required:    true,
-									Description: "Node id which you want to attach",
+									Optional:    true,
+									Default:     "",
+									Description: "Node id which you want to attach. Exactly one of id or label is required",
+								},
+								"label": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Default:     "",
+									Description: "Attach every running node carrying this label. Exactly one of id or label is required",
+								},
+								"ip_type": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Default:     "private",
+									Description: "Which ip of the node is used as backend ip, private (VPC/private network) or public",
+									ValidateFunc: validation.StringInSlice([]string{
+										"private",
+										"public",
+									}, false),
 								},
 								"port": {
 									Type:        schema.TypeString,
@@ -258,9 +297,12 @@ func ResouceLoadBalancerSchema() map[string]*schema.Schema {
 			Description: "List of vpc Id which you want to attach",
 		},
 		"enable_eos_logger": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			Description: "If you want to get the logs of loadbalancer. Please connect eos bucket",
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			Deprecated:    "Use access_logs instead",
+			ConflictsWith: []string{"access_logs"},
+			Description:   "If you want to get the logs of loadbalancer. Please connect eos bucket",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"appliance_id": {
@@ -287,6 +329,47 @@ func ResouceLoadBalancerSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"access_logs": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"enable_eos_logger"},
+			Description:   "Ship the access logs of the load balancer to an object storage bucket",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"bucket": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Name of the e2e_objectstore bucket receiving the logs",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"access_key": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Access key of an e2e_objectstore_access_key with access to the bucket",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"secret_key": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Sensitive:    true,
+						Description:  "Secret key of the e2e_objectstore_access_key",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"region": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "",
+						Description: "Region of the bucket, defaults to the location of the load balancer",
+					},
+				},
+			},
+		},
+		"access_logs_enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the load balancer is currently shipping access logs to object storage",
+		},
 		"tcp_backend": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -329,10 +412,27 @@ func ResouceLoadBalancerSchema() map[string]*schema.Schema {
 							Schema: map[string]*schema.Schema{
 								"id": {
 									Type:         schema.TypeString,
-									Required:     true,
-									Description:  "Node id which you want to attach",
+									Optional:     true,
+									Default:      "",
+									Description:  "Node id which you want to attach. Exactly one of id or label is required",
 									ValidateFunc: node.ValidateName,
 								},
+								"label": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Default:     "",
+									Description: "Attach every running node carrying this label. Exactly one of id or label is required",
+								},
+								"ip_type": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Default:     "private",
+									Description: "Which ip of the node is used as backend ip, private (VPC/private network) or public",
+									ValidateFunc: validation.StringInSlice([]string{
+										"private",
+										"public",
+									}, false),
+								},
 								"port": {
 									Type:        schema.TypeString,
 									Required:    true,
@@ -402,6 +502,40 @@ func ResouceLoadBalancerSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "This is the status of your loadbalancer, only to get the status from my account.",
 		},
+		"resolved_servers": serversSchema("Servers currently configured on the load balancer after resolving node ids, labels and ip types"),
+		"selected_servers": serversSchema("Servers the node ids, labels and scaler groups of the backends resolved to on the last refresh. A difference with resolved_servers plans an update of the load balancer"),
+	}
+}
+
+func serversSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"backend": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the backend the server belongs to",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the node",
+				},
+				"ip": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "IP address used to reach the node",
+				},
+				"port": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Port of the node",
+				},
+			},
+		},
 	}
 }
 
@@ -425,7 +559,15 @@ func CreateLoadBalancerObject(apiClient *client.Client, d *schema.ResourceData)
 	}
 	enableEosLogger, ok := d.GetOk("enable_eos_logger")
 	if ok {
-		eosDetail, err := ExpandEnableEosLogger(enableEosLogger.(*schema.Set).List())
+		eosDetail, err := ExpandEnableEosLogger(enableEosLogger.([]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		loadBalancerObj.EnableEosLogger = eosDetail
+	}
+	accessLogs, ok := d.GetOk("access_logs")
+	if ok {
+		eosDetail, err := ExpandAccessLogs(accessLogs.([]interface{}), apiClient, d.Get("project_id").(string), d.Get("location").(string))
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -552,6 +694,27 @@ func resourceReadLoadBalancer(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("lb_name", data["name"].(string))
 	d.Set("plan_name", node_detail["plan_name"].(string))
 	d.Set("lb_mode", lb_context["lb_mode"].(string))
+	served := FlattenLbContextServers(lb_context)
+	d.Set("resolved_servers", served)
+
+	// Drift of the selectors is found here rather than when planning, so a
+	// node being down only warns instead of blocking every plan.
+	selected, err := ResolveServers(d.Get("backends").([]interface{}), d.Get("tcp_backend").([]interface{}), apiClient, d.Get("project_id").(string), location)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Load balancer servers could not be resolved",
+			Detail:   fmt.Sprintf("The servers of load balancer %s are left as they are: %s", lbId, err),
+		})
+		selected = served
+	}
+	d.Set("selected_servers", selected)
+
+	accessLogs := FlattenAccessLogs(d, lb_context)
+	d.Set("access_logs_enabled", len(accessLogs) > 0)
+	if _, ok := d.GetOk("enable_eos_logger"); !ok {
+		d.Set("access_logs", accessLogs)
+	}
 
 	if d.Get("is_ipv6_attached").(bool) == true {
 		if lb_context["host_target_ipv6"] != nil {
@@ -688,6 +851,35 @@ func resourceDeleteLoadBalancer(ctx context.Context, d *schema.ResourceData, m i
 	return diags
 }
 
+// resourceDiffLoadBalancer plans an update when the servers the selectors
+// resolved to on the last refresh differ from the servers the load balancer
+// is serving, so nodes joining or leaving a label, or changing ip, show up as
+// a diff. It also rejects a static node_list_type for scaler group backends.
+func resourceDiffLoadBalancer(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.NewValueKnown("backends") && UsesScalerGroup(diff.Get("backends").([]interface{})) && diff.Get("node_list_type").(string) != "D" {
+		return fmt.Errorf("backends referencing a scaler_group need node_list_type = \"D\"")
+	}
+	if diff.Id() == "" {
+		return nil
+	}
+	if diff.HasChange("backends") || diff.HasChange("tcp_backend") {
+		if err := diff.SetNewComputed("selected_servers"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("resolved_servers")
+	}
+	selected := diff.Get("selected_servers").([]interface{})
+	if len(selected) == 0 {
+		// Not resolved by a refresh yet.
+		return nil
+	}
+	if ResolvedServersEqual(diff.Get("resolved_servers").([]interface{}), selected) {
+		return nil
+	}
+	log.Printf("[INFO] LOAD BALANCER DIFF | resolved servers drifted from the load balancer configuration")
+	return diff.SetNew("resolved_servers", selected)
+}
+
 func resourceExistsLoadBalancer(d *schema.ResourceData, m interface{}) (bool, error) {
 	return true, nil
 }
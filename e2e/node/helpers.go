@@ -1,10 +1,12 @@
 package node
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
@@ -59,3 +61,41 @@ func GetStartScripts(start_script string) []interface{} {
 	}
 	return startScripts
 }
+
+// planCommittedSKUs returns a lookup of the committed SKUs of a node plan.
+func planCommittedSKUs(apiClient *client.Client, plan, image, project_id, location string) func() ([]models.PlanCommittedSKU, error) {
+	return func() ([]models.PlanCommittedSKU, error) {
+		plans, err := apiClient.GetNodePlans(image, project_id, location)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range plans {
+			if p.Plan == plan || p.Name == plan {
+				return p.CommittedSKUs, nil
+			}
+		}
+		return nil, fmt.Errorf("plan %s isn't offered for image %s", plan, image)
+	}
+}
+
+// flattenCommittedDetails reads the committed term of a node read through
+// GetNode.
+func flattenCommittedDetails(data map[string]interface{}) []models.CommittedSKU {
+	var details []models.CommittedSKU
+	items, _ := data["committed_details"].([]interface{})
+	for _, item := range items {
+		detail, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := detail["committed_sku_id"].(float64)
+		days, _ := detail["committed_days"].(float64)
+		uptoDate, _ := detail["committed_upto_date"].(string)
+		details = append(details, models.CommittedSKU{
+			ID:       int(id),
+			Days:     int(days),
+			UptoDate: uptoDate,
+		})
+	}
+	return details
+}
@@ -15,6 +15,7 @@ import (
 	//"time"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/constants"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/committed"
 
 	// "github.com/e2eterraformprovider/terraform-provider-e2e/e2e/security_group"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/models"
@@ -28,7 +29,7 @@ import (
 )
 
 func ResourceNode() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Schema: map[string]*schema.Schema{
 
 			"name": {
@@ -238,10 +239,21 @@ func ResourceNode() *schema.Resource {
 		UpdateContext: resourceUpdateNode,
 		DeleteContext: resourceDeleteNode,
 		Exists:        resourceExistsNode,
+		CustomizeDiff: resourceNodeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: CustomImportStateFunc,
 		},
 	}
+	committed.AddSchema(r.Schema)
+	return r
+}
+
+func resourceNodeCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("plan") || !diff.NewValueKnown("image") {
+		return nil
+	}
+	lookup := planCommittedSKUs(m.(*client.Client), diff.Get("plan").(string), diff.Get("image").(string), diff.Get("project_id").(string), diff.Get("location").(string))
+	return committed.ValidateDiff(diff, []string{"plan"}, lookup)
 }
 
 func ValidateName(v interface{}, k string) (ws []string, es []error) {
@@ -341,6 +353,15 @@ func resourceCreateNode(ctx context.Context, d *schema.ResourceData, m interface
 		Image_id:                image_id,
 	}
 
+	sku, err := committed.Resolve(d, planCommittedSKUs(apiClient, node.Plan, node.Image, d.Get("project_id").(string), d.Get("location").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if sku != nil {
+		node.CN_id = sku.SKUID
+		node.CN_status = d.Get("committed_term_end_action").(string)
+	}
+
 	if node.Vpc_id != "" {
 		vpc_details, err := apiClient.GetVpc(node.Vpc_id, d.Get("project_id").(string), d.Get("location").(string))
 		if err != nil {
@@ -416,6 +437,7 @@ func resourceReadNode(ctx context.Context, d *schema.ResourceData, m interface{}
 	d.Set("is_bitninja_license_active", data["is_bitninja_license_active"].(bool))
 	d.Set("ssh_keys", copy_ssh_keys)
 	d.Set("vm_id", int(data["vm_id"].(float64)))
+	committed.Set(d, flattenCommittedDetails(data))
 
 	log.Printf("[info] node Resource read | after setting data")
 	if d.Get("status").(string) == "Running" || d.Get("status").(string) == "Creating" {
@@ -749,6 +771,16 @@ func resourceUpdateNode(ctx context.Context, d *schema.ResourceData, m interface
 		}
 	}
 
+	if d.HasChanges("committed_sku", "committed_days", "committed_term_end_action") {
+		lookup := planCommittedSKUs(apiClient, d.Get("plan").(string), d.Get("image").(string), project_id, location)
+		err := committed.Update(d, lookup, func(settings *models.CommittedSettingsRequest) error {
+			return apiClient.UpdateNodeCommittedSettings(nodeId, settings, project_id, location)
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceReadNode(ctx, d, m)
 
 }
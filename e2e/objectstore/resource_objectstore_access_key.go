@@ -0,0 +1,111 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/node"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceObjectStoreAccessKey() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name (tag) of the access key.",
+				ValidateFunc: node.ValidateName,
+			},
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The My-Account Project where the access key will be created.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Region the access key will be created",
+			},
+			"access_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The access key used to authenticate against object storage",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret key of the access key. It is only returned when the key is created",
+			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is the access key disabled?",
+			},
+		},
+		CreateContext: resourceCreateAccessKey,
+		ReadContext:   resourceReadAccessKey,
+		DeleteContext: resourceDeleteAccessKey,
+	}
+}
+
+func resourceCreateAccessKey(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] ACCESS KEY CREATE STARTS ")
+	projectID := fmt.Sprint(d.Get("project_id").(int))
+	accessKey, err := apiClient.CreateAccessKey(d.Get("name").(string), d.Get("region").(string), projectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if accessKey.AccessKey == "" {
+		return diag.Errorf("access key was not returned while creating %s", d.Get("name").(string))
+	}
+	d.SetId(strconv.Itoa(int(math.Round(accessKey.ID))))
+	d.Set("access_key", accessKey.AccessKey)
+	d.Set("secret_key", accessKey.SecretKey)
+	d.Set("is_disabled", accessKey.IsDisabled)
+	return diags
+}
+
+func resourceReadAccessKey(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	projectID := fmt.Sprint(d.Get("project_id").(int))
+	accessKey, err := apiClient.GetAccessKey(d.Get("access_key").(string), d.Get("region").(string), projectID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error finding access key %s: %s", d.Get("name").(string), err)
+	}
+	d.Set("name", accessKey.Tag)
+	d.Set("is_disabled", accessKey.IsDisabled)
+	return diags
+}
+
+func resourceDeleteAccessKey(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	var diags diag.Diagnostics
+
+	projectID := fmt.Sprint(d.Get("project_id").(int))
+	err := apiClient.DeleteAccessKey(d.Id(), d.Get("access_key").(string), d.Get("region").(string), projectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
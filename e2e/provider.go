@@ -2,11 +2,20 @@ package e2e
 
 import (
 	"github.com/e2eterraformprovider/terraform-provider-e2e/client"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/autoscaling"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/blockstorage"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/container_registry"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_database"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_kafka"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_mariadb"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_mongodb"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_mysql"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_parameter_group"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_postgress"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_read_replica"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_redis"
+	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/dbaas_snapshot"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/image"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/kubernetes"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/loadbalancer"
@@ -18,8 +27,6 @@ import (
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/ssh_key"
 	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/vpc"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/e2eterraformprovider/terraform-provider-e2e/e2e/autoscaling"
-	
 )
 
 func Provider() *schema.Provider {
@@ -56,11 +63,22 @@ func Provider() *schema.Provider {
 			"e2e_blockstorage":       blockstorage.ResourceBlockStorage(),
 			"e2e_sfs":                sfs.ResourceSfs(),
 			"e2e_objectstore":        objectstore.ResourceObjectStore(),
+			"e2e_objectstore_access_key": objectstore.ResourceObjectStoreAccessKey(),
 			"e2e_ssh_key":            ssh_key.ResourceSshKey(),
 			"e2e_kubernetes":         kubernetes.ResourceKubernetesService(),
+			"e2e_kubernetes_node_pool": kubernetes.ResourceKubernetesNodePool(),
+			"e2e_kubernetes_addon":   kubernetes.ResourceKubernetesAddon(),
 			"e2e_dbaas_postgresql":   dbaas_postgress.ResourcePostgresDBaaS(),
 			"e2e_dbaas_mysql":        dbaas_mysql.ResourceMySql(),
 			"e2e_dbaas_mariadb":      dbaas_mariadb.ResourceMariaDB(),
+			"e2e_dbaas_mongodb":      dbaas_mongodb.ResourceMongoDB(),
+			"e2e_dbaas_redis":        dbaas_redis.ResourceRedis(),
+			"e2e_dbaas_kafka":        dbaas_kafka.ResourceKafka(),
+			"e2e_dbaas_parameter_group": dbaas_parameter_group.ResourceParameterGroup(),
+			"e2e_dbaas_snapshot":     dbaas_snapshot.ResourceSnapshot(),
+			"e2e_dbaas_read_replica": dbaas_read_replica.ResourceReadReplica(),
+			"e2e_dbaas_user":         dbaas_database.ResourceUser(),
+			"e2e_dbaas_database":     dbaas_database.ResourceDatabase(),
 			"e2e_container_registry": container_registry.ResourceContainerRegistry(),
 			"e2e_scaler_group":       autoscaling.ResourceScalerGroup(),
 		},
@@ -77,11 +95,19 @@ func Provider() *schema.Provider {
 			"e2e_sfss":               sfs.DataSourceSfs(),
 			"e2e_objectstores":       objectstore.DataSourceObjectStores(),
 			"e2e_kubernetes":         kubernetes.DataSourceKubernetesService(),
+			"e2e_kubernetes_config":  kubernetes.DataSourceKubernetesConfig(),
+			"e2e_kubernetes_versions": kubernetes.DataSourceKubernetesVersions(),
 			"e2e_dbaas_postgresql":   dbaas_postgress.DataSourcePostgresDBaaS(),
 			"e2e_dbaas_mysql":        dbaas_mysql.DataSourceMySQLDBaaS(),
 			"e2e_dbaas_mariadb":      dbaas_mariadb.DataSourceMariaDB(),
+			"e2e_dbaas_mongodb":      dbaas_mongodb.DataSourceMongoDB(),
+			"e2e_dbaas_redis":        dbaas_redis.DataSourceRedis(),
+			"e2e_dbaas_kafka":        dbaas_kafka.DataSourceKafka(),
+			"e2e_dbaas_plans":        dbaas.DataSourcePlans(),
+			"e2e_dbaas_snapshot":     dbaas_snapshot.DataSourceSnapshot(),
 			"e2e_container_registry": container_registry.DataSourceContainerRegistry(),
 			"e2e_scaler_group":       autoscaling.DataSourceScalerGroup(),
+			"e2e_scaler_groups":      autoscaling.DataSourceScalerGroups(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -16,7 +16,7 @@ type LoadBalancerCreate struct {
 	AclList          []AclListInfo          `json:"acl_list"`
 	AclMap           []AclMapInfo           `json:"acl_map"`
 	VpcList          []VpcDetail            `json:"vpc_list"`
-	EnableEosLogger  EosDetail              `json:"enable_eos_logger,omitempty"`
+	EnableEosLogger  *EosDetail             `json:"enable_eos_logger,omitempty"`
 	TcpBackend       []TcpBackendDetail     `json:"tcp_backend"`
 	IsIpv6Attached   bool                   `json:"is_ipv6_attached"`
 	DefaultBackend   string                 `json:"default_backend"`
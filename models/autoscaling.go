@@ -43,9 +43,27 @@ type CreateScalerGroupRequest struct {
 	MaxNodes             string            `json:"max_nodes"`
 	Desired              string            `json:"desired"`
 	PolicyType           string            `json:"policy_type,omitempty"`
+	CustomParamName      string            `json:"custom_param_name,omitempty"`
 	Policy               []ElasticPolicy   `json:"policy,omitempty"`
 	ScheduledPolicy      []ScheduledPolicy `json:"scheduled_policy,omitempty"`
 	VPC                  []VPCDetail       `json:"vpc,omitempty"`
+
+	LifecycleHooks *ScalerGroupLifecycleHooks `json:"lifecycle_hooks,omitempty"`
+	HealthCheck    *ScalerGroupHealthCheck    `json:"health_check,omitempty"`
+}
+
+type ScalerGroupLifecycleHooks struct {
+	StartScripts []interface{} `json:"start_scripts"`
+	DrainTimeout int           `json:"drain_timeout"`
+}
+
+type ScalerGroupHealthCheck struct {
+	Enabled            bool   `json:"enabled"`
+	Type               string `json:"type"`
+	Port               int    `json:"port,omitempty"`
+	Interval           int    `json:"interval"`
+	UnhealthyThreshold int    `json:"unhealthy_threshold"`
+	GracePeriod        int    `json:"grace_period"`
 }
 
 type VPCDetail struct {
@@ -145,6 +163,16 @@ type ScalerGroupGetDetail struct {
 	UpscaleAdjust           int              `json:"upscale_adjust"`
 	DownscaleRecurrence     string           `json:"downscale_recurrence"`
 	DownscaleAdjust         int              `json:"downscale_adjust"`
+
+	LifecycleHooks *ScalerGroupLifecycleHooks `json:"lifecycle_hooks"`
+	HealthCheck    *ScalerGroupHealthCheck    `json:"health_check"`
+}
+
+type ListScalerGroupsResponse struct {
+	Code    int                    `json:"code"`
+	Message string                 `json:"message"`
+	Errors  map[string]string      `json:"errors"`
+	Data    []ScalerGroupGetDetail `json:"data"`
 }
 
 type DeleteScalerGroupResponse struct {
@@ -184,20 +212,38 @@ type GetScalerSecurityGroupsResponse struct {
 }
 
 type UpdateScalerGroupRequest struct {
-	Name            string            `json:"name"`
-	PlanID          string            `json:"plan_id"`
-	MinNodes        int               `json:"min_nodes"`
-	MaxNodes        int               `json:"max_nodes"`
-	PolicyType      string            `json:"policy_type,omitempty"`
+	Name            string `json:"name"`
+	PlanID          string `json:"plan_id"`
+	MinNodes        int    `json:"min_nodes"`
+	MaxNodes        int    `json:"max_nodes"`
+	PolicyType      string `json:"policy_type,omitempty"`
+	CustomParamName string `json:"custom_param_name,omitempty"`
+
+	VMImageID            string `json:"vm_image_id,omitempty"`
+	VMImageName          string `json:"vm_image_name,omitempty"`
+	VMTemplateID         int    `json:"vm_template_id,omitempty"`
+	IsEncryptionEnabled  bool   `json:"isEncryptionEnabled"`
+	EncryptionPassphrase string `json:"encryption_passphrase,omitempty"`
 
 	Policy          []ElasticPolicy   `json:"policy"`
 	ScheduledPolicy []ScheduledPolicy `json:"scheduled_policy"`
+
+	LifecycleHooks *ScalerGroupLifecycleHooks `json:"lifecycle_hooks,omitempty"`
+	HealthCheck    *ScalerGroupHealthCheck    `json:"health_check,omitempty"`
 }
 
 type UpdateDesiredNodeCountRequest struct {
 	Cardinality int `json:"cardinality"`
 }
 
+// RemoveScalerGroupNodesRequest removes members of a scaler group. With
+// DecrementDesired the group shrinks by the removed nodes, otherwise it
+// launches replacements for them.
+type RemoveScalerGroupNodesRequest struct {
+	NodeIDs          []int `json:"node_ids"`
+	DecrementDesired bool  `json:"decrement_desired"`
+}
+
 type AttachVPCRequest struct {
 	VPCID string `json:"vpc_id"`
 }
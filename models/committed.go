@@ -0,0 +1,20 @@
+package models
+
+// CommittedSettingsRequest converts an hourly resource to a committed SKU
+// and sets what happens when the committed term ends.
+type CommittedSettingsRequest struct {
+	CNID     int    `json:"cn_id,omitempty"`
+	CNStatus string `json:"cn_status"`
+}
+
+type NodePlansResponse struct {
+	Code    int        `json:"code"`
+	Data    []NodePlan `json:"data"`
+	Message string     `json:"message"`
+}
+
+type NodePlan struct {
+	Name          string             `json:"name"`
+	Plan          string             `json:"plan"`
+	CommittedSKUs []PlanCommittedSKU `json:"committed_sku"`
+}
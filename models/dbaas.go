@@ -23,6 +23,9 @@ type DB struct {
 	ZookeeperInstances  int      `json:"zookeeper_instances"`
 	SlaveInstances      int      `json:"slave_instances"`
 	IsEncryptionEnabled bool     `json:"isEncryptionEnabled"`
+
+	MaintenanceWindow *DBMaintenanceWindow `json:"maintenance_window"`
+	BackupSettings    *DBBackupSettings    `json:"backup_settings"`
 }
 
 type Software struct {
@@ -87,17 +90,6 @@ type Plan struct {
 	CommittedSKU           []CommittedSKU `json:"committed_sku"`
 }
 
-type MySqlCreate struct {
-	Name             string   `json:"name"`
-	Database         DBConfig `json:"database"`
-	Vpcs             []VPC    `json:"vpcs"`
-	SoftwareID       int      `json:"software_id"`
-	TemplateID       int      `json:"template_id"`
-	ParameterGroupId int      `json:"pg_id,omitempty"`
-	PublicIPRequired bool     `json:"public_ip_required"`
-	Group            string   `json:"group"`
-}
-
 type CommittedSKU struct {
 	ID       int     `json:"committed_sku_id"`
 	Name     string  `json:"committed_sku_name"`
@@ -107,49 +99,31 @@ type CommittedSKU struct {
 	Days     int     `json:"committed_days"`
 }
 
-type MariaDBCreateRequest struct {
-	Name                 string        `json:"name"`
-	SoftwareID           int           `json:"software_id"`
-	TemplateID           int           `json:"template_id"`
-	PublicIPRequired     bool          `json:"public_ip_required"`
-	Group                string        `json:"group"`
-	VPCs                 []VPCMetadata `json:"vpcs,omitempty"`
-	Database             DBConfig      `json:"database"`
-	PGID                 int           `json:"pg_id"`
-	IsEncryptionEnabled  bool          `json:"isEncryptionEnabled"`
-	EncryptionPassphrase string        `json:"encryption_passphrase"`
-}
-
 type DBCreateRequest struct {
-	Name                string   `json:"name"`
-	SoftwareID          int      `json:"software_id"`
-	TemplateID          int      `json:"template_id"`
-	PublicIPRequired    bool     `json:"public_ip_required"`
-	Group               string   `json:"group"`
-	VPCs                []VPC    `json:"vpcs"`
-	Database            DBConfig `json:"database"`
-	PGID                *int     `json:"pg_id,omitempty"`
-	IsEncryptionEnabled bool     `json:"isEncryptionEnabled"`
+	Name                 string   `json:"name"`
+	SoftwareID           int      `json:"software_id"`
+	TemplateID           int      `json:"template_id"`
+	PublicIPRequired     bool     `json:"public_ip_required"`
+	Group                string   `json:"group"`
+	VPCs                 []VPC    `json:"vpcs"`
+	Database             DBConfig `json:"database"`
+	PGID                 *int     `json:"pg_id,omitempty"`
+	IsEncryptionEnabled  bool     `json:"isEncryptionEnabled"`
+	EncryptionPassphrase string   `json:"encryption_passphrase,omitempty"`
+	CNID                 int      `json:"cn_id,omitempty"`
+	CNStatus             string   `json:"cn_status,omitempty"`
+	SnapshotID           int      `json:"snapshot_id,omitempty"`
+	SourceClusterID      int      `json:"source_cluster_id,omitempty"`
+	PointInTime          string   `json:"point_in_time,omitempty"`
 }
 
 type DBConfig struct {
-	User        string `json:"user"`
+	User        string `json:"user,omitempty"`
 	Password    string `json:"password"`
-	Name        string `json:"name"`
+	Name        string `json:"name,omitempty"`
 	DBaaSNumber int    `json:"dbaas_number"`
 }
 
-type AttachDetachVPCRequest struct {
-	Action string        `json:"action"`
-	VPCs   []VPCMetadata `json:"vpcs"`
-}
-
-type VPCMetadata struct {
-	NetworkID string `json:"network_id"`
-	VPCName   string `json:"vpc_name"`
-	IPv4CIDR  string `json:"ipv4_cidr"`
-}
-
 type ParameterGroupRequest struct {
 	Action string `json:"action"`
 }
@@ -166,14 +140,6 @@ type PGDetail struct {
 	ID int `json:"pg_id"`
 }
 
-type MySQlPlanUpgradeAction struct {
-	TemplateID int `json:"template_id"`
-}
-
-type MYSQLExpandDisk struct {
-	Size int `json:"size"`
-}
-
 type AttachVPCPayloadRequest struct {
 	Action string `json:"action"`
 	VPCs   []VPC  `json:"vpcs"`
@@ -184,3 +150,24 @@ type VPC struct {
 	Ipv4_cidr  string  `json:"ipv4_cidr,omitempty"`
 	Network_id float64 `json:"network_id,omitempty"`
 }
+
+type AllowedIPRequest struct {
+	Action      string      `json:"action"`
+	IPAddresses []AllowedIP `json:"ip_addresses"`
+}
+
+type AllowedIP struct {
+	IP  string `json:"ip"`
+	Tag string `json:"tag"`
+}
+
+type DBMaintenanceWindow struct {
+	Day       string `json:"day"`
+	StartTime string `json:"start_time"`
+}
+
+type DBBackupSettings struct {
+	StartTime     string `json:"start_time,omitempty"`
+	RetentionDays int    `json:"retention_days,omitempty"`
+	PITREnabled   bool   `json:"pitr_enabled"`
+}
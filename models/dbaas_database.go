@@ -0,0 +1,46 @@
+package models
+
+type DBUserRequest struct {
+	Username   string   `json:"username,omitempty"`
+	Password   string   `json:"password,omitempty"`
+	Privileges []string `json:"privileges"`
+	Databases  []string `json:"databases"`
+}
+
+type DBUserPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+type DBUsersResponse struct {
+	Code    int      `json:"code"`
+	Data    []DBUser `json:"data"`
+	Errors  any      `json:"errors"`
+	Message string   `json:"message"`
+}
+
+type DBUser struct {
+	Username   string   `json:"username"`
+	Privileges []string `json:"privileges"`
+	Databases  []string `json:"databases"`
+}
+
+type DBDatabaseRequest struct {
+	Name         string `json:"name,omitempty"`
+	CharacterSet string `json:"character_set,omitempty"`
+	Collation    string `json:"collation,omitempty"`
+	Owner        string `json:"owner,omitempty"`
+}
+
+type DBDatabasesResponse struct {
+	Code    int          `json:"code"`
+	Data    []DBDatabase `json:"data"`
+	Errors  any          `json:"errors"`
+	Message string       `json:"message"`
+}
+
+type DBDatabase struct {
+	Name         string `json:"name"`
+	CharacterSet string `json:"character_set"`
+	Collation    string `json:"collation"`
+	Owner        string `json:"owner"`
+}
@@ -0,0 +1,41 @@
+package models
+
+type ParameterGroupCreateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SoftwareID  int    `json:"software_id"`
+}
+
+type ParameterGroupUpdateRequest struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Parameters  []ParameterGroupParameter `json:"parameters"`
+}
+
+type ParameterGroupResponse struct {
+	Code    int            `json:"code"`
+	Data    ParameterGroup `json:"data"`
+	Errors  any            `json:"errors"`
+	Message string         `json:"message"`
+}
+
+type ParameterGroup struct {
+	ID          int                       `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Software    Software                  `json:"software"`
+	Parameters  []ParameterGroupParameter `json:"parameters"`
+}
+
+// ParameterGroupParameter is a database setting of a parameter group.
+// DataType is one of integer, float, boolean or string, ApplyType is static
+// for settings only applied on restart.
+type ParameterGroupParameter struct {
+	Name          string `json:"name"`
+	Value         string `json:"value"`
+	DataType      string `json:"data_type,omitempty"`
+	DefaultValue  string `json:"default_value,omitempty"`
+	AllowedValues string `json:"allowed_values,omitempty"`
+	ApplyType     string `json:"apply_type,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
@@ -12,14 +12,6 @@ type PlanData struct {
 	DatabaseEngines []EngineDefinition `json:"database_engines"`
 }
 
-type PostgressPlanUpgradeAction struct {
-	TemplateID int `json:"template_id"`
-}
-
-type PostgressDiskAction struct {
-	Size int `json:"size"`
-}
-
 type PlanTemplate struct {
 	PlanName             string             `json:"name"`
 	PlanDisplayPrice     string             `json:"price"`
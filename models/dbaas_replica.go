@@ -0,0 +1,20 @@
+package models
+
+type DBReplicaCreateRequest struct {
+	Name       string `json:"name"`
+	TemplateID int    `json:"template_id,omitempty"`
+}
+
+type DBReplicaResponse struct {
+	Code    int    `json:"code"`
+	Data    DBNode `json:"data"`
+	Errors  any    `json:"errors"`
+	Message string `json:"message"`
+}
+
+type DBReplicasResponse struct {
+	Code    int      `json:"code"`
+	Data    []DBNode `json:"data"`
+	Errors  any      `json:"errors"`
+	Message string   `json:"message"`
+}
@@ -0,0 +1,27 @@
+package models
+
+type DBSnapshotCreateRequest struct {
+	Name string `json:"name"`
+}
+
+type DBSnapshotResponse struct {
+	Code    int        `json:"code"`
+	Data    DBSnapshot `json:"data"`
+	Errors  any        `json:"errors"`
+	Message string     `json:"message"`
+}
+
+type DBSnapshotsResponse struct {
+	Code    int          `json:"code"`
+	Data    []DBSnapshot `json:"data"`
+	Errors  any          `json:"errors"`
+	Message string       `json:"message"`
+}
+
+type DBSnapshot struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Size      string `json:"size"`
+	CreatedAt string `json:"created_at"`
+}
@@ -63,6 +63,12 @@ type KubernetesCreate struct {
 	NodePools       []NodePool `json:"node_pools"`
 }
 
+type KubernetesUpgrade struct {
+	Version  string `json:"version"`
+	SlugName string `json:"slug_name,omitempty"`
+	SKUID    string `json:"sku_id,omitempty"`
+}
+
 type NodePoolUpdate struct {
 	MinVms           int                `json:"min_vms"`
 	Cardinality      int                `json:"cardinality"`
@@ -82,3 +88,9 @@ type NodePoolAdd struct {
 type NodePoolResize struct {
 	NodePoolSize int `json:"cardinality"`
 }
+
+type KubernetesAddon struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Values  string `json:"values,omitempty"`
+}
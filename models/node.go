@@ -20,6 +20,8 @@ type NodeCreate struct {
 	SSH_keys                []interface{} `json:"ssh_keys"`
 	Start_scripts           []interface{} `json:"start_scripts"`
 	Image_id                int           `json:"image_id"`
+	CN_id                   int           `json:"cn_id,omitempty"`
+	CN_status               string        `json:"cn_status,omitempty"`
 }
 type NodeAction struct {
 	Type string `json:"type"`
@@ -46,6 +48,7 @@ type ResponseNodes struct {
 type Node struct {
 	ID               float64 `json:"id"`
 	Name             string  `json:"name"`
+	Label            string  `json:"label"`
 	Status           string  `json:"status"`
 	PublicIPAddress  string  `json:"public_ip_address"`
 	PrivateIPAddress string  `json:"private_ip_address"`
@@ -22,3 +22,29 @@ type ResponseBuckets struct {
 	Error   string        `json:"error"`
 	Message string        `json:"message"`
 }
+
+type AccessKey struct {
+	ID         float64 `json:"id"`
+	Tag        string  `json:"tag"`
+	AccessKey  string  `json:"access_key"`
+	SecretKey  string  `json:"secret_key"`
+	IsDisabled bool    `json:"disabled"`
+}
+
+type AccessKeyPayload struct {
+	Tag string `json:"tag"`
+}
+
+type ResponseAccessKey struct {
+	Code    int       `json:"code"`
+	Data    AccessKey `json:"data"`
+	Error   string    `json:"error"`
+	Message string    `json:"message"`
+}
+
+type ResponseAccessKeys struct {
+	Code    int         `json:"code"`
+	Data    []AccessKey `json:"data"`
+	Error   string      `json:"error"`
+	Message string      `json:"message"`
+}